@@ -0,0 +1,163 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package awsbase
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/smithy-go/middleware"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var attributeSetters = struct {
+	mu      sync.RWMutex
+	setters map[string][]otelaws.AttributeSetter
+}{
+	setters: builtinAttributeSetters(),
+}
+
+// RegisterAttributeSetter registers an AttributeSetter for operations of the AWS service with the given service ID.
+// The attributes it returns are added to the log fields, spans, and metrics of each operation of the service.
+// Setters are called in the order they are registered, after the built-in setters for the service.
+func RegisterAttributeSetter(serviceID string, setter otelaws.AttributeSetter) {
+	attributeSetters.mu.Lock()
+	defer attributeSetters.mu.Unlock()
+
+	attributeSetters.setters[serviceID] = append(attributeSetters.setters[serviceID], setter)
+}
+
+// attributeSettersFor returns the AttributeSetters registered for the AWS service with the given service ID.
+func attributeSettersFor(serviceID string) []otelaws.AttributeSetter {
+	attributeSetters.mu.RLock()
+	defer attributeSetters.mu.RUnlock()
+
+	return attributeSetters.setters[serviceID]
+}
+
+const (
+	lambdaFunctionNameKey attribute.Key = "aws.lambda.function_name"
+	snsTopicARNKey        attribute.Key = "aws.sns.topic.arn"
+	kinesisStreamNameKey  attribute.Key = "aws.kinesis.stream_name"
+	kinesisStreamARNKey   attribute.Key = "aws.kinesis.stream_arn"
+	ec2InstanceIDKey      attribute.Key = "aws.ec2.instance_id"
+	ec2InstanceIDsKey     attribute.Key = "aws.ec2.instance_ids"
+	iamRoleNameKey        attribute.Key = "aws.iam.role_name"
+)
+
+// The service IDs of services whose client packages are not imported by this module.
+const (
+	ec2ServiceID     = "EC2"
+	kinesisServiceID = "Kinesis"
+	lambdaServiceID  = "Lambda"
+)
+
+func builtinAttributeSetters() map[string][]otelaws.AttributeSetter {
+	return map[string][]otelaws.AttributeSetter{
+		dynamodb.ServiceID: {otelaws.DynamoDBAttributeSetter},
+		ec2ServiceID: {inputFieldAttributeSetter(
+			inputField{name: "InstanceId", key: ec2InstanceIDKey},
+			inputField{name: "InstanceIds", key: ec2InstanceIDsKey},
+		)},
+		iam.ServiceID: {inputFieldAttributeSetter(
+			inputField{name: "RoleName", key: iamRoleNameKey},
+		)},
+		kinesisServiceID: {inputFieldAttributeSetter(
+			inputField{name: "StreamName", key: kinesisStreamNameKey},
+			inputField{name: "StreamARN", key: kinesisStreamARNKey},
+		)},
+		lambdaServiceID: {inputFieldAttributeSetter(
+			inputField{name: "FunctionName", key: lambdaFunctionNameKey},
+		)},
+		s3.ServiceID:  {s3AttributeSetter},
+		sns.ServiceID: {snsAttributeSetter},
+		sqs.ServiceID: {otelaws.SQSAttributeSetter},
+	}
+}
+
+var snsTopicARNAttributeSetter = inputFieldAttributeSetter(
+	inputField{name: "TopicArn", key: snsTopicARNKey},
+)
+
+// snsAttributeSetter sets the OpenTelemetry messaging attributes for SNS publish operations
+// and the topic ARN for all other SNS operations.
+func snsAttributeSetter(ctx context.Context, in middleware.InitializeInput) []attribute.KeyValue {
+	switch in.Parameters.(type) {
+	case *sns.PublishInput, *sns.PublishBatchInput:
+		return otelaws.SNSAttributeSetter(ctx, in)
+	}
+
+	return snsTopicARNAttributeSetter(ctx, in)
+}
+
+// inputField maps a field of an operation input to an attribute.
+type inputField struct {
+	name string
+	key  attribute.Key
+}
+
+// inputFieldAttributeSetter returns an AttributeSetter which sets attributes from the named fields of operation inputs.
+// Fields of type string, *string, and []string are supported. Fields which are missing or not set are skipped.
+// This avoids depending on the client packages of services with large APIs.
+func inputFieldAttributeSetter(fields ...inputField) otelaws.AttributeSetter {
+	return func(_ context.Context, in middleware.InitializeInput) []attribute.KeyValue {
+		v := reflect.ValueOf(in.Parameters)
+		if v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return nil
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			return nil
+		}
+
+		var attributes []attribute.KeyValue
+		for _, field := range fields {
+			if kv, ok := fieldAttribute(v.FieldByName(field.name), field.key); ok {
+				attributes = append(attributes, kv)
+			}
+		}
+		return attributes
+	}
+}
+
+func fieldAttribute(v reflect.Value, key attribute.Key) (kv attribute.KeyValue, ok bool) {
+	if !v.IsValid() {
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() || v.Elem().Kind() != reflect.String {
+			return
+		}
+		return key.String(v.Elem().String()), true
+
+	case reflect.String:
+		if v.Len() == 0 {
+			return
+		}
+		return key.String(v.String()), true
+
+	case reflect.Slice:
+		if v.Len() == 0 || v.Type().Elem().Kind() != reflect.String {
+			return
+		}
+		values := make([]string, v.Len())
+		for i := range values {
+			values[i] = v.Index(i).String()
+		}
+		return key.StringSlice(values), true
+	}
+
+	return
+}
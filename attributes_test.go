@@ -0,0 +1,254 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package awsbase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/smithy-go/middleware"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// The following types have the same shape as the corresponding AWS SDK for Go v2 operation inputs,
+// so that the client packages of these services are not dependencies of this module.
+
+type lambdaInvokeInput struct {
+	FunctionName *string
+	Payload      []byte
+	Qualifier    *string
+}
+
+type kinesisPutRecordInput struct {
+	Data         []byte
+	PartitionKey *string
+	StreamARN    *string
+	StreamName   *string
+}
+
+type ec2DescribeInstancesInput struct {
+	DryRun      *bool
+	InstanceIds []string
+}
+
+type ec2GetConsoleOutputInput struct {
+	InstanceId *string
+}
+
+func TestAttributeSettersLambda(t *testing.T) {
+	input := middleware.InitializeInput{
+		Parameters: &lambdaInvokeInput{
+			FunctionName: aws.String("test-function"),
+			Payload:      []byte(`{"key":"value"}`),
+			Qualifier:    aws.String("1"),
+		},
+	}
+
+	attributes := serviceAttributes(context.TODO(), lambdaServiceID, input)
+
+	assertAttributesMatch(t, attributes,
+		[]attribute.KeyValue{
+			attribute.String("aws.lambda.function_name", "test-function"),
+		},
+	)
+}
+
+func TestAttributeSettersSNSPublish(t *testing.T) {
+	input := middleware.InitializeInput{
+		Parameters: &sns.PublishInput{
+			Message:  aws.String("message"),
+			TopicArn: aws.String("arn:aws:sns:us-west-2:123456789012:test-topic"),
+		},
+	}
+
+	attributes := serviceAttributes(context.TODO(), sns.ServiceID, input)
+
+	assertAttributesMatch(t, attributes,
+		[]attribute.KeyValue{
+			attribute.String("messaging.system", "aws_sns"),
+			attribute.String("messaging.destination.name", "test-topic"),
+			attribute.String("messaging.operation.type", "publish"),
+			attribute.String("messaging.operation.name", "publish_input"),
+		},
+	)
+}
+
+func TestAttributeSettersSNSSubscribe(t *testing.T) {
+	input := middleware.InitializeInput{
+		Parameters: &sns.SubscribeInput{
+			Endpoint: aws.String("test@example.com"),
+			Protocol: aws.String("email"),
+			TopicArn: aws.String("arn:aws:sns:us-west-2:123456789012:test-topic"),
+		},
+	}
+
+	attributes := serviceAttributes(context.TODO(), sns.ServiceID, input)
+
+	assertAttributesMatch(t, attributes,
+		[]attribute.KeyValue{
+			attribute.String("aws.sns.topic.arn", "arn:aws:sns:us-west-2:123456789012:test-topic"),
+		},
+	)
+}
+
+func TestAttributeSettersKinesisStreamName(t *testing.T) {
+	input := middleware.InitializeInput{
+		Parameters: &kinesisPutRecordInput{
+			Data:         []byte("data"),
+			PartitionKey: aws.String("key"),
+			StreamName:   aws.String("test-stream"),
+		},
+	}
+
+	attributes := serviceAttributes(context.TODO(), kinesisServiceID, input)
+
+	assertAttributesMatch(t, attributes,
+		[]attribute.KeyValue{
+			attribute.String("aws.kinesis.stream_name", "test-stream"),
+		},
+	)
+}
+
+func TestAttributeSettersKinesisStreamARN(t *testing.T) {
+	input := middleware.InitializeInput{
+		Parameters: &kinesisPutRecordInput{
+			Data:         []byte("data"),
+			PartitionKey: aws.String("key"),
+			StreamARN:    aws.String("arn:aws:kinesis:us-west-2:123456789012:stream/test-stream"),
+		},
+	}
+
+	attributes := serviceAttributes(context.TODO(), kinesisServiceID, input)
+
+	assertAttributesMatch(t, attributes,
+		[]attribute.KeyValue{
+			attribute.String("aws.kinesis.stream_arn", "arn:aws:kinesis:us-west-2:123456789012:stream/test-stream"),
+		},
+	)
+}
+
+func TestAttributeSettersEC2InstanceIDs(t *testing.T) {
+	input := middleware.InitializeInput{
+		Parameters: &ec2DescribeInstancesInput{
+			DryRun:      aws.Bool(true),
+			InstanceIds: []string{"i-1234567890abcdef0", "i-0598c7d356eba48d7"},
+		},
+	}
+
+	attributes := serviceAttributes(context.TODO(), ec2ServiceID, input)
+
+	assertAttributesMatch(t, attributes,
+		[]attribute.KeyValue{
+			attribute.StringSlice("aws.ec2.instance_ids", []string{"i-1234567890abcdef0", "i-0598c7d356eba48d7"}),
+		},
+	)
+}
+
+func TestAttributeSettersEC2InstanceIDsNotSet(t *testing.T) {
+	input := middleware.InitializeInput{
+		Parameters: &ec2DescribeInstancesInput{},
+	}
+
+	attributes := serviceAttributes(context.TODO(), ec2ServiceID, input)
+
+	assertAttributesMatch(t, attributes, nil)
+}
+
+func TestAttributeSettersEC2InstanceID(t *testing.T) {
+	input := middleware.InitializeInput{
+		Parameters: &ec2GetConsoleOutputInput{
+			InstanceId: aws.String("i-1234567890abcdef0"),
+		},
+	}
+
+	attributes := serviceAttributes(context.TODO(), ec2ServiceID, input)
+
+	assertAttributesMatch(t, attributes,
+		[]attribute.KeyValue{
+			attribute.String("aws.ec2.instance_id", "i-1234567890abcdef0"),
+		},
+	)
+}
+
+func TestAttributeSettersIAMGetRole(t *testing.T) {
+	input := middleware.InitializeInput{
+		Parameters: &iam.GetRoleInput{
+			RoleName: aws.String("test-role"),
+		},
+	}
+
+	attributes := serviceAttributes(context.TODO(), iam.ServiceID, input)
+
+	assertAttributesMatch(t, attributes,
+		[]attribute.KeyValue{
+			attribute.String("aws.iam.role_name", "test-role"),
+		},
+	)
+}
+
+func TestAttributeSettersIAMGetUser(t *testing.T) {
+	input := middleware.InitializeInput{
+		Parameters: &iam.GetUserInput{
+			UserName: aws.String("test-user"),
+		},
+	}
+
+	attributes := serviceAttributes(context.TODO(), iam.ServiceID, input)
+
+	assertAttributesMatch(t, attributes, nil)
+}
+
+func TestAttributeSettersNilInput(t *testing.T) {
+	input := middleware.InitializeInput{
+		Parameters: (*lambdaInvokeInput)(nil),
+	}
+
+	attributes := serviceAttributes(context.TODO(), lambdaServiceID, input)
+
+	assertAttributesMatch(t, attributes, nil)
+}
+
+func TestRegisterAttributeSetter(t *testing.T) {
+	const serviceID = "TestRegisterAttributeSetter"
+
+	t.Cleanup(func() {
+		attributeSetters.mu.Lock()
+		defer attributeSetters.mu.Unlock()
+
+		delete(attributeSetters.setters, serviceID)
+	})
+
+	RegisterAttributeSetter(serviceID, inputFieldAttributeSetter(
+		inputField{name: "FunctionName", key: "test.name"},
+	))
+	RegisterAttributeSetter(serviceID, func(context.Context, middleware.InitializeInput) []attribute.KeyValue {
+		return []attribute.KeyValue{attribute.String("test.key", "value")}
+	})
+
+	input := middleware.InitializeInput{
+		Parameters: &lambdaInvokeInput{
+			FunctionName: aws.String("test-function"),
+		},
+	}
+
+	attributes := serviceAttributes(context.TODO(), serviceID, input)
+
+	assertAttributesMatch(t, attributes,
+		[]attribute.KeyValue{
+			attribute.String("test.name", "test-function"),
+			attribute.String("test.key", "value"),
+		},
+	)
+}
+
+func serviceAttributes(ctx context.Context, serviceID string, in middleware.InitializeInput) []attribute.KeyValue {
+	var attributes []attribute.KeyValue
+	for _, setter := range attributeSettersFor(serviceID) {
+		attributes = append(attributes, setter(ctx, in)...)
+	}
+	return attributes
+}
@@ -45,6 +45,7 @@ func GetAwsConfig(ctx context.Context, c *Config) (context.Context, aws.Config,
 	if c.Logger != nil {
 		logger = c.Logger
 	}
+	logger = logging.NewMaskingLogger(logger)
 	ctx = logging.RegisterLogger(ctx, logger)
 	ctx = configCommonLogging(ctx)
 
@@ -77,11 +78,33 @@ func GetAwsConfig(ctx context.Context, c *Config) (context.Context, aws.Config,
 		}
 	}
 
+	if err := endpoints.LoadEnvironmentPartitions(); err != nil {
+		return ctx, aws.Config{}, diags.AddSimpleError(fmt.Errorf("loading partitions: %w", err))
+	}
+
 	c.ValidateProxySettings(&diags)
+	c.ValidateRegion(&diags)
+	c.ValidateEndpoints(&diags)
+	c.ValidateAssumeRoleARNs(&diags)
+	c.ValidateAssumeRolePolicies(&diags)
 	if diags.HasError() {
 		return ctx, aws.Config{}, diags
 	}
 
+	if c.MeterProvider != nil {
+		metrics, err := newAPIMetrics(c.MeterProvider)
+		if err != nil {
+			return ctx, aws.Config{}, diags.AddSimpleError(fmt.Errorf("creating metrics: %w", err))
+		}
+		baseCtx = withAPIMetrics(baseCtx, metrics)
+	}
+
+	if c.EmulatorMode() {
+		logger.Info(baseCtx, "Using emulator endpoint for all services", map[string]any{
+			"tf_aws.emulator_endpoint": c.EmulatorEndpoint,
+		})
+	}
+
 	logger.Debug(baseCtx, "Resolving credentials provider")
 	var (
 		credentialsProvider aws.CredentialsProvider
@@ -113,9 +136,23 @@ func GetAwsConfig(ctx context.Context, c *Config) (context.Context, aws.Config,
 		var d diag.Diagnostics
 		credentialsProvider, initialSource, d = getCredentialsProvider(baseCtx, c)
 		if d.HasError() {
-			return ctx, aws.Config{}, diags.Append(d...)
+			if !c.EmulatorMode() || len(c.AssumeRole) > 0 || c.AssumeRoleWithWebIdentity != nil {
+				return ctx, aws.Config{}, diags.Append(d...)
+			}
+			logger.Info(baseCtx, "No credentials found, using placeholder credentials for emulator")
+			credentialsProvider = credentials.NewStaticCredentialsProvider(
+				emulatorAccessKey,
+				emulatorSecretKey,
+				"",
+			)
+			initialSource = ""
 		}
 	}
+	// Credentials are cached here, rather than by LoadDefaultConfig, so that their refreshes are counted.
+	// Credentials returned by getCredentialsProvider are already cached.
+	if _, ok := credentialsProvider.(*aws.CredentialsCache); !ok {
+		credentialsProvider = newCredentialsCache(baseCtx, credentialsProvider)
+	}
 	creds, err := credentialsProvider.Retrieve(baseCtx)
 	if err != nil {
 		return ctx, aws.Config{}, diags.AddSimpleError(fmt.Errorf("retrieving credentials: %w", err))
@@ -163,6 +200,19 @@ func GetAwsConfig(ctx context.Context, c *Config) (context.Context, aws.Config,
 		return ctx, aws.Config{}, diags.AddSimpleError(fmt.Errorf("loading configuration: %w", err))
 	}
 
+	addServiceEndpointsConfigSource(&awsConfig, c)
+	logServiceEndpoints(baseCtx, awsConfig, c)
+
+	if c.UseFIPSEndpoint && c.FIPSEndpointMode == FIPSEndpointModeWhereAvailable {
+		if serviceIDs := fipsFallbackServices(awsConfig.Region); len(serviceIDs) > 0 {
+			diags = diags.AddWarning(
+				"FIPS Endpoints Not Available",
+				fmt.Sprintf("The following services do not have FIPS endpoints in Region %q and will use standard endpoints: %s",
+					awsConfig.Region, strings.Join(serviceIDs, ", ")),
+			)
+		}
+	}
+
 	if staticCreds {
 		if c.AssumeRole != nil {
 			provider, d := assumeRoleCredentialsProvider(baseCtx, awsConfig, c)
@@ -177,9 +227,11 @@ func GetAwsConfig(ctx context.Context, c *Config) (context.Context, aws.Config,
 	resolveRetryer(baseCtx, c, &awsConfig)
 
 	if !c.SkipCredsValidation {
-		if _, _, err := getAccountIDAndPartitionFromSTSGetCallerIdentity(baseCtx, stsClient(baseCtx, awsConfig, c)); err != nil {
+		identity, err := getCallerIdentityFromSTS(baseCtx, stsClient(baseCtx, awsConfig, c))
+		if err != nil {
 			return ctx, awsConfig, diags.AddSimpleError(fmt.Errorf("validating provider credentials: %w", err))
 		}
+		ctx = withCallerIdentity(ctx, identity)
 	}
 
 	return ctx, awsConfig, diags
@@ -230,12 +282,19 @@ func resolveRetryer(ctx context.Context, c *Config, awsConfig *aws.Config) {
 		})
 	}
 
+	// Throttling errors are classified the same way by the adaptive retry mode and the API metrics.
+	throttles := slices.Clone(retry.DefaultThrottles)
+
 	newRetryer := func(retryMode aws.RetryMode, standardOptions []func(*retry.StandardOptions)) aws.RetryerV2 {
 		var retryer aws.RetryerV2
 
 		switch retryMode {
 		case aws.RetryModeAdaptive:
-			var adaptiveOptions []func(*retry.AdaptiveModeOptions)
+			adaptiveOptions := []func(*retry.AdaptiveModeOptions){
+				func(ao *retry.AdaptiveModeOptions) {
+					ao.Throttles = throttles
+				},
+			}
 			if len(standardOptions) != 0 {
 				adaptiveOptions = append(adaptiveOptions, func(ao *retry.AdaptiveModeOptions) {
 					ao.StandardOptions = append(ao.StandardOptions, standardOptions...)
@@ -256,6 +315,10 @@ func resolveRetryer(ctx context.Context, c *Config, awsConfig *aws.Config) {
 			RetryerV2: newRetryer(retryMode, slices.Clone(standardOptions)),
 		}
 	}
+
+	if metrics := apiMetricsFromContext(ctx); metrics != nil {
+		metrics.setThrottles(throttles)
+	}
 }
 
 // Adapted from the per-service-client `setResolvedDefaultsMode()` functions in the AWS SDK for Go v2
@@ -303,6 +366,7 @@ func GetAwsAccountIDAndPartition(ctx context.Context, awsConfig aws.Config, c *C
 	if c.Logger != nil {
 		logger = c.Logger
 	}
+	logger = logging.NewMaskingLogger(logger)
 	ctx = configCommonLogging(ctx)
 	ctx, logger = logger.SubLogger(ctx, loggerName)
 	ctx = logging.RegisterLogger(ctx, logger)
@@ -337,9 +401,18 @@ func GetAwsAccountIDAndPartition(ctx context.Context, awsConfig aws.Config, c *C
 				"Errors: %w", err))
 	}
 
-	partition, _ := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), awsConfig.Region)
+	// Emulators accept arbitrary Region names, so an unknown Region is not reported in emulator mode.
+	partition, kind := endpoints.MatchPartitionForRegion(endpoints.DefaultPartitions(), awsConfig.Region)
+	if kind == endpoints.PartitionMatchKindRegionRegex && !c.EmulatorMode() {
+		diags = diags.AddWarning(
+			"Partition Inferred From Region",
+			fmt.Sprintf("The AWS Region %q is not a known Region. The partition %q was inferred from the Region name and may be incorrect.\n\n"+
+				"To determine the partition from the AWS account, do not skip credentials validation or requesting the account ID.",
+				awsConfig.Region, partition.ID()),
+		)
+	}
 
-	return "", partition.ID(), nil
+	return "", partition.ID(), diags
 }
 
 func commonLoadOptions(ctx context.Context, c *Config) ([]func(*config.LoadOptions) error, error) {
@@ -392,19 +465,55 @@ func commonLoadOptions(ctx context.Context, c *Config) ([]func(*config.LoadOptio
 				return stack.Initialize.Add(&logAttributeExtractor{}, middleware.After)
 			},
 			func(stack *middleware.Stack) error {
-				return stack.Deserialize.Add(&requestResponseLogger{}, middleware.After)
+				return stack.Finalize.Add(&resolvedEndpointRecorder{config: c}, middleware.After)
+			},
+			func(stack *middleware.Stack) error {
+				return stack.Deserialize.Add(&requestResponseLogger{
+					maxRequestBodyLen:  c.MaxRequestBodyLogLength,
+					maxResponseBodyLen: c.MaxResponseBodyLogLength,
+				}, middleware.After)
 			},
 		)
 	}
 
+	if c.TracerProvider != nil {
+		apiOptions = append(apiOptions, withTracing(c.TracerProvider)...)
+	}
+
+	if metrics := apiMetricsFromContext(ctx); metrics != nil {
+		apiOptions = append(apiOptions, withMetrics(metrics))
+	}
+
+	if c.UseFIPSEndpoint && c.FIPSEndpointMode == FIPSEndpointModeWhereAvailable {
+		apiOptions = append(apiOptions, fipsFallback())
+	}
+
+	imdsEnableState := c.EC2MetadataServiceEnableState
+	if c.EmulatorMode() {
+		s3PathStyle, err := emulatorS3PathStyle(c.EmulatorEndpoint)
+		if err != nil {
+			return nil, err
+		}
+		apiOptions = append(apiOptions, s3PathStyle)
+
+		// Emulators do not provide an EC2 Instance Metadata Service
+		imdsEnableState = imds.ClientDisabled
+	}
+
 	loadOptions := []func(*config.LoadOptions) error{
 		config.WithRegion(c.Region),
 		config.WithHTTPClient(httpClient),
 		config.WithAPIOptions(apiOptions),
-		config.WithEC2IMDSClientEnableState(c.EC2MetadataServiceEnableState),
+		config.WithEC2IMDSClientEnableState(imdsEnableState),
 		config.WithLogConfigurationWarnings(true),
 	}
 
+	if c.EmulatorMode() {
+		loadOptions = append(loadOptions,
+			config.WithBaseEndpoint(c.EmulatorEndpoint),
+		)
+	}
+
 	if !c.SuppressDebugLog {
 		loadOptions = append(
 			loadOptions,
@@ -469,9 +578,9 @@ func commonLoadOptions(ctx context.Context, c *Config) ([]func(*config.LoadOptio
 	}
 
 	// This should not be needed, but https://github.com/aws/aws-sdk-go-v2/issues/1398
-	if c.EC2MetadataServiceEnableState == imds.ClientEnabled {
+	if imdsEnableState == imds.ClientEnabled {
 		os.Setenv("AWS_EC2_METADATA_DISABLED", "false")
-	} else if c.EC2MetadataServiceEnableState == imds.ClientDisabled {
+	} else if imdsEnableState == imds.ClientDisabled {
 		os.Setenv("AWS_EC2_METADATA_DISABLED", "true")
 	}
 
@@ -3528,6 +3528,34 @@ func TestGetAwsConfigWithAccountIDAndPartition(t *testing.T) {
 			expectedAcctID: "", expectedPartition: "aws",
 			mockStsEndpoints: []*servicemocks.MockEndpoint{},
 		},
+		{
+			desc: "SkipRequestingAccountId_UnknownRegion",
+			config: &Config{
+				AccessKey:               "MockAccessKey",
+				SecretKey:               "MockSecretKey",
+				Region:                  "us-west-17",
+				SkipCredsValidation:     true,
+				SkipRequestingAccountId: true},
+			expectedAcctID: "", expectedPartition: "aws",
+			mockStsEndpoints: []*servicemocks.MockEndpoint{},
+			ExpectedDiags: diag.Diagnostics{
+				diag.NewWarningDiagnostic(
+					"Partition Inferred From Region",
+					"The AWS Region \"us-west-17\" is not a known Region. The partition \"aws\" was inferred from the Region name and may be incorrect.\n\n"+
+						"To determine the partition from the AWS account, do not skip credentials validation or requesting the account ID.",
+				),
+			},
+		},
+		{
+			desc: "SkipRequestingAccountId_UnknownRegion_EmulatorMode",
+			config: &Config{
+				EmulatorEndpoint:        "http://localhost:4566",
+				Region:                  "us-west-17",
+				SkipCredsValidation:     true,
+				SkipRequestingAccountId: true},
+			expectedAcctID: "", expectedPartition: "aws",
+			mockStsEndpoints: []*servicemocks.MockEndpoint{},
+		},
 		{
 			desc: "WithAssumeRole",
 			config: &Config{
@@ -3981,6 +4009,8 @@ func TestLogger_TfLog(t *testing.T) {
 		// Custom attributes
 		string(logging.AwsSdkKey):         awsSdkGoV2Val,
 		string(logging.CustomEndpointKey): true,
+		string(logging.EndpointKey):       ts.URL,
+		string(logging.SigningNameKey):    "sts",
 		"http.request.body":               requestBody + "\n",
 		// HTTP attributes
 		string(semconv.HTTPMethodKey):                                "POST",
@@ -4028,6 +4058,8 @@ func TestLogger_TfLog(t *testing.T) {
 		// Custom attributes
 		string(logging.AwsSdkKey):         awsSdkGoV2Val,
 		string(logging.CustomEndpointKey): true,
+		string(logging.EndpointKey):       ts.URL,
+		string(logging.SigningNameKey):    "sts",
 		// HTTP attributes
 		string(semconv.HTTPStatusCodeKey):                          float64(http.StatusOK),
 		string(logging.ResponseHeaderAttributeKey("Content-Type")): "text/xml",
@@ -4102,6 +4134,69 @@ func TestLoggerDefaultMasking_TfLog(t *testing.T) {
 	}
 }
 
+func TestLoggerCredentialMasking(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	ctx = tflogtest.RootLogger(ctx, &buf)
+
+	servicemocks.InitSessionTestEnv(t)
+
+	ctx, logger := logging.NewTfLogger(ctx)
+
+	config := &Config{
+		AccessKey: servicemocks.MockStaticAccessKey,
+		AssumeRole: []AssumeRole{{
+			RoleARN:     servicemocks.MockStsAssumeRoleArn,
+			SessionName: servicemocks.MockStsAssumeRoleSessionName,
+		}},
+		Logger:    logger,
+		Region:    "us-east-1",
+		SecretKey: servicemocks.MockStaticSecretKey,
+	}
+
+	ts := servicemocks.MockAwsApiServer("STS", []*servicemocks.MockEndpoint{
+		servicemocks.MockStsAssumeRoleValidEndpoint,
+		servicemocks.MockStsGetCallerIdentityValidEndpoint,
+	})
+	defer ts.Close()
+	config.StsEndpoint = ts.URL
+
+	_, _, diags := GetAwsConfig(ctx, config)
+	if diags.HasError() {
+		t.Fatalf("error in GetAwsConfig(): %v", diags)
+	}
+
+	lines, err := tflogtest.MultilineJSONDecode(&buf)
+	if err != nil {
+		t.Fatalf("decoding log lines: %s", err)
+	}
+
+	var responseLines []map[string]any
+	for _, line := range lines {
+		if line["@message"] == "HTTP Response Received" && line[string(semconv.RPCMethodKey)] == "AssumeRole" {
+			responseLines = append(responseLines, line)
+		}
+	}
+	if len(responseLines) == 0 {
+		t.Fatalf("expected AssumeRole response lines, had none")
+	}
+
+	for i, line := range responseLines {
+		body, ok := line["http.response.body"].(string)
+		if !ok {
+			t.Fatalf("line %d: expected response body, got %v", i+1, line["http.response.body"])
+		}
+		for _, v := range []string{servicemocks.MockStsAssumeRoleSecretKey, servicemocks.MockStsAssumeRoleSessionToken} {
+			if strings.Contains(body, v) {
+				t.Errorf("line %d: expected %q to be masked in response body: %s", i+1, v, body)
+			}
+		}
+		if !strings.Contains(body, servicemocks.MockStsAssumeRoleAccessKey) {
+			t.Errorf("line %d: expected access key ID in response body: %s", i+1, body)
+		}
+	}
+}
+
 func TestLogger_HcLog(t *testing.T) {
 	ctx := context.Background()
 
@@ -4197,6 +4292,8 @@ func TestLogger_HcLog(t *testing.T) {
 		// Custom attributes
 		string(logging.AwsSdkKey):         awsSdkGoV2Val,
 		string(logging.CustomEndpointKey): true,
+		string(logging.EndpointKey):       ts.URL,
+		string(logging.SigningNameKey):    "sts",
 		"http.request.body":               requestBody + "\n",
 		// HTTP attributes
 		string(semconv.HTTPMethodKey):                                "POST",
@@ -4244,6 +4341,8 @@ func TestLogger_HcLog(t *testing.T) {
 		// Custom attributes
 		string(logging.AwsSdkKey):         awsSdkGoV2Val,
 		string(logging.CustomEndpointKey): true,
+		string(logging.EndpointKey):       ts.URL,
+		string(logging.SigningNameKey):    "sts",
 		// HTTP attributes
 		string(semconv.HTTPStatusCodeKey):                          float64(http.StatusOK),
 		string(logging.ResponseHeaderAttributeKey("Content-Type")): "text/xml",
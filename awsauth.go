@@ -167,6 +167,15 @@ func getAccountIDAndPartitionFromIAMListRoles(ctx context.Context, iamClient iam
 // getAccountIDAndPartitionFromSTSGetCallerIdentity gets the account ID and associated
 // partition from STS caller identity.
 func getAccountIDAndPartitionFromSTSGetCallerIdentity(ctx context.Context, stsClient *sts.Client) (accountID string, partition string, err error) {
+	identity, err := getCallerIdentityFromSTS(ctx, stsClient)
+	if err != nil {
+		return "", "", err
+	}
+	return identity.AccountID, identity.Partition, nil
+}
+
+// getCallerIdentityFromSTS gets the caller identity from STS.
+func getCallerIdentityFromSTS(ctx context.Context, stsClient *sts.Client) (*CallerIdentity, error) {
 	logger := logging.RetrieveLogger(ctx)
 
 	logger.Debug(ctx, "Retrieving caller identity from STS")
@@ -176,26 +185,32 @@ func getAccountIDAndPartitionFromSTSGetCallerIdentity(ctx context.Context, stsCl
 		logger.Debug(ctx, "Unable to retrieve caller identity from STS", map[string]any{
 			"error": err,
 		})
-		return "", "", fmt.Errorf("retrieving caller identity from STS: %w", err)
+		return nil, fmt.Errorf("retrieving caller identity from STS: %w", err)
 	}
 
 	if output == nil || output.Arn == nil {
 		logger.Debug(ctx, "Unable to retrieve caller identity from STS", map[string]any{
 			"error": "empty response",
 		})
-		return "", "", errors.New("retrieving caller identity from STS: empty response")
+		return nil, errors.New("retrieving caller identity from STS: empty response")
 	}
 
-	accountID, partition, err = parseAccountIDAndPartitionFromARN(aws.ToString(output.Arn))
+	accountID, partition, err := parseAccountIDAndPartitionFromARN(aws.ToString(output.Arn))
 	if err != nil {
 		logger.Debug(ctx, "Unable to retrieve caller identity from STS", map[string]any{
 			"error": err,
 		})
-		return "", "", fmt.Errorf("retrieving caller identity from STS: %w", err)
-	} else {
-		logger.Info(ctx, "Retrieved caller identity from STS")
+		return nil, fmt.Errorf("retrieving caller identity from STS: %w", err)
 	}
-	return
+
+	logger.Info(ctx, "Retrieved caller identity from STS")
+
+	return &CallerIdentity{
+		AccountID: accountID,
+		ARN:       aws.ToString(output.Arn),
+		Partition: partition,
+		UserID:    aws.ToString(output.UserId),
+	}, nil
 }
 
 func parseAccountIDAndPartitionFromARN(inputARN string) (string, string, error) {
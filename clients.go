@@ -16,11 +16,11 @@ func iamClient(ctx context.Context, awsConfig aws.Config, c *Config) *iam.Client
 	logger := logging.RetrieveLogger(ctx)
 
 	return iam.NewFromConfig(awsConfig, func(opts *iam.Options) {
-		if c.IamEndpoint != "" {
+		if endpoint := serviceEndpoint(c, iam.ServiceID); endpoint != "" {
 			logger.Info(ctx, "IAM client: setting custom endpoint", map[string]any{
-				"tf_aws.iam_client.endpoint": c.IamEndpoint,
+				"tf_aws.iam_client.endpoint": endpoint,
 			})
-			opts.EndpointResolver = iam.EndpointResolverFromURL(c.IamEndpoint) //nolint:staticcheck // The replacement is not documented yet (2023/07/31)
+			setIAMEndpoint(opts, endpoint)
 		}
 	})
 }
@@ -35,11 +35,11 @@ func stsClient(ctx context.Context, awsConfig aws.Config, c *Config) *sts.Client
 			})
 			opts.Region = c.StsRegion
 		}
-		if c.StsEndpoint != "" {
+		if endpoint := serviceEndpoint(c, sts.ServiceID); endpoint != "" {
 			logger.Info(ctx, "STS client: setting custom endpoint", map[string]any{
-				"tf_aws.sts_client.endpoint": c.StsEndpoint,
+				"tf_aws.sts_client.endpoint": endpoint,
 			})
-			opts.EndpointResolver = sts.EndpointResolverFromURL(c.StsEndpoint) //nolint:staticcheck // The replacement is not documented yet (2023/07/31)
+			setSTSEndpoint(opts, endpoint)
 		}
 	})
 }
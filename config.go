@@ -38,3 +38,10 @@ const (
 	HTTPProxyModeLegacy   = config.HTTPProxyModeLegacy
 	HTTPProxyModeSeparate = config.HTTPProxyModeSeparate
 )
+
+type FIPSEndpointMode = config.FIPSEndpointMode
+
+const (
+	FIPSEndpointModeAll            = config.FIPSEndpointModeAll
+	FIPSEndpointModeWhereAvailable = config.FIPSEndpointModeWhereAvailable
+)
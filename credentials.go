@@ -34,9 +34,9 @@ func getCredentialsProvider(ctx context.Context, c *Config) (aws.CredentialsProv
 	}
 	loadOptions = append(
 		loadOptions,
-		// The endpoint resolver is added here instead of in commonLoadOptions() so that it
-		// is not included in the aws.Config returned to the caller
-		config.WithEndpointResolverWithOptions(credentialsEndpointResolver(ctx, c)),
+		// The endpoint options are added here instead of in commonLoadOptions() so that they
+		// are not included in the aws.Config returned to the caller
+		credentialsEndpointOptions(ctx, c)...,
 	)
 
 	envConfig, err := config.NewEnvConfig()
@@ -71,6 +71,7 @@ func getCredentialsProvider(ctx context.Context, c *Config) (aws.CredentialsProv
 	if err != nil {
 		return nil, "", diags.AddSimpleError(err)
 	}
+	cfg.Credentials = newCredentialsCache(ctx, cfg.Credentials)
 
 	// This can probably be configured directly in commonLoadOptions() once
 	// https://github.com/aws/aws-sdk-go-v2/pull/1682 is merged
@@ -149,7 +150,7 @@ func webIdentityCredentialsProvider(ctx context.Context, awsConfig aws.Config, c
 	if _, err := appCreds.Retrieve(ctx); err != nil {
 		return nil, diags.Append(c.NewCannotAssumeRoleWithWebIdentityError(err))
 	}
-	return aws.NewCredentialsCache(appCreds), diags
+	return newCredentialsCache(ctx, appCreds), diags
 }
 
 func assumeRoleCredentialsProvider(ctx context.Context, awsConfig aws.Config, c *Config) (aws.CredentialsProvider, diag.Diagnostics) {
@@ -220,7 +221,7 @@ func assumeRoleCredentialsProvider(ctx context.Context, awsConfig aws.Config, c
 		if err != nil {
 			return nil, diags.Append(newCannotAssumeRoleError(ar, err))
 		}
-		creds = aws.NewCredentialsCache(appCreds)
+		creds = newCredentialsCache(ctx, appCreds)
 		awsConfig.Credentials = creds
 	}
 	return creds, nil
@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package awsbase
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+// Emulators such as LocalStack and moto accept any credentials.
+// These placeholder credentials are used in emulator mode when no other credentials are configured.
+const (
+	emulatorAccessKey = "test"
+	emulatorSecretKey = "test"
+)
+
+// emulatorS3PathStyle returns an API option which rewrites S3 virtual-hosted-style requests to the emulator
+// as path-style requests.
+// S3 addressing style is a client option and cannot be set on aws.Config, so the rewrite is done after endpoint resolution
+// and before the request is signed.
+func emulatorS3PathStyle(endpoint string) (func(*middleware.Stack) error, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("parsing emulator endpoint: %w", err)
+	}
+
+	return func(stack *middleware.Stack) error {
+		if _, ok := stack.Finalize.Get("ResolveEndpointV2"); !ok {
+			return nil
+		}
+		return stack.Finalize.Insert(emulatorS3PathStyleMiddleware(u.Host), "ResolveEndpointV2", middleware.After)
+	}, nil
+}
+
+func emulatorS3PathStyleMiddleware(host string) middleware.FinalizeMiddleware {
+	return middleware.FinalizeMiddlewareFunc("EmulatorS3PathStyle", func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (
+		out middleware.FinalizeOutput, metadata middleware.Metadata, err error,
+	) {
+		if awsmiddleware.GetServiceID(ctx) != s3.ServiceID {
+			return next.HandleFinalize(ctx, in)
+		}
+
+		req, ok := in.Request.(*smithyhttp.Request)
+		if !ok {
+			return next.HandleFinalize(ctx, in)
+		}
+
+		if bucket, ok := strings.CutSuffix(req.URL.Host, "."+host); ok && bucket != "" {
+			req.URL.Host = host
+			req.URL.Path = "/" + bucket + req.URL.Path
+			if req.URL.RawPath != "" {
+				req.URL.RawPath = "/" + bucket + req.URL.RawPath
+			}
+		}
+
+		return next.HandleFinalize(ctx, in)
+	})
+}
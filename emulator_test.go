@@ -0,0 +1,161 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package awsbase
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"slices"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/hashicorp/aws-sdk-go-base/v2/diag"
+	"github.com/hashicorp/aws-sdk-go-base/v2/servicemocks"
+)
+
+func TestEmulatorMode(t *testing.T) {
+	servicemocks.InitSessionTestEnv(t)
+
+	// Credentials served by IMDS must not be used, since IMDS is disabled in emulator mode
+	closeEc2Metadata := servicemocks.AwsMetadataApiMock(append(
+		servicemocks.Ec2metadata_securityCredentialsEndpoints,
+		servicemocks.Ec2metadata_instanceIdEndpoint,
+		servicemocks.Ec2metadata_iamInfoEndpoint,
+	))
+	defer closeEc2Metadata()
+
+	ts := servicemocks.MockAwsApiServer("Emulator", []*servicemocks.MockEndpoint{
+		servicemocks.MockStsGetCallerIdentityValidEndpoint,
+		{
+			Request: &servicemocks.MockRequest{
+				Method: http.MethodHead,
+				Uri:    "/test-bucket/",
+			},
+			Response: &servicemocks.MockResponse{
+				StatusCode: http.StatusOK,
+			},
+		},
+	})
+	defer ts.Close()
+
+	// The endpoint uses a hostname, so that S3 would otherwise use virtual-hosted-style addressing
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("parsing mock server URL: %s", err)
+	}
+	endpoint := "http://localhost:" + u.Port()
+
+	c := &Config{
+		EmulatorEndpoint:    endpoint,
+		Region:              "us-east-1",
+		SkipCredsValidation: true,
+	}
+
+	ctx, awsConfig, diags := GetAwsConfig(context.Background(), c)
+	if diags.HasError() {
+		t.Fatalf("error in GetAwsConfig(): %v", diags)
+	}
+
+	creds, err := awsConfig.Credentials.Retrieve(ctx)
+	if err != nil {
+		t.Fatalf("retrieving credentials: %s", err)
+	}
+	if a, e := creds.AccessKeyID, emulatorAccessKey; a != e {
+		t.Errorf("expected access key %q, got %q", e, a)
+	}
+	if a, e := creds.Source, credentials.StaticCredentialsName; a != e {
+		t.Errorf("expected credentials source %q, got %q", e, a)
+	}
+
+	dynamodbClient := dynamodb.NewFromConfig(awsConfig)
+	if a, e := aws.ToString(dynamodbClient.Options().BaseEndpoint), endpoint; a != e {
+		t.Errorf("expected DynamoDB base endpoint %q, got %q", e, a)
+	}
+
+	if _, err := s3.NewFromConfig(awsConfig).HeadBucket(ctx, &s3.HeadBucketInput{
+		Bucket: aws.String("test-bucket"),
+	}); err != nil {
+		t.Errorf("calling S3 HeadBucket: %s", err)
+	}
+
+	output, err := sts.NewFromConfig(awsConfig).GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		t.Fatalf("calling STS GetCallerIdentity: %s", err)
+	}
+	if a, e := aws.ToString(output.Account), servicemocks.MockStsGetCallerIdentityAccountID; a != e {
+		t.Errorf("expected account ID %q, got %q", e, a)
+	}
+}
+
+func TestEmulatorModeNonAWSRegion(t *testing.T) {
+	servicemocks.InitSessionTestEnv(t)
+
+	ts := servicemocks.MockAwsApiServer("Emulator", []*servicemocks.MockEndpoint{
+		servicemocks.MockStsGetCallerIdentityValidEndpoint,
+	})
+	defer ts.Close()
+
+	c := &Config{
+		EmulatorEndpoint:    ts.URL,
+		Region:              "local",
+		SkipCredsValidation: true,
+	}
+
+	ctx, awsConfig, diags := GetAwsConfig(context.Background(), c)
+	if diags.HasError() {
+		t.Fatalf("error in GetAwsConfig(): %v", diags)
+	}
+
+	if a, e := awsConfig.Region, "local"; a != e {
+		t.Errorf("expected region %q, got %q", e, a)
+	}
+
+	if _, err := sts.NewFromConfig(awsConfig).GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{}); err != nil {
+		t.Errorf("calling STS GetCallerIdentity: %s", err)
+	}
+
+	// Without the emulator endpoint, the Region is rejected.
+	c.EmulatorEndpoint = ""
+	c.AccessKey = servicemocks.MockStaticAccessKey
+	c.SecretKey = servicemocks.MockStaticSecretKey
+
+	_, _, diags = GetAwsConfig(context.Background(), c)
+	if !slices.ContainsFunc(diags, func(d diag.Diagnostic) bool {
+		return d.Summary() == "Invalid AWS Region"
+	}) {
+		t.Errorf("expected Invalid AWS Region error, got %v", diags)
+	}
+}
+
+func TestEmulatorModeServiceEndpointOverride(t *testing.T) {
+	servicemocks.InitSessionTestEnv(t)
+
+	c := &Config{
+		AccessKey:        servicemocks.MockStaticAccessKey,
+		SecretKey:        servicemocks.MockStaticSecretKey,
+		EmulatorEndpoint: "http://localhost:4566",
+		Endpoints: map[string]string{
+			"DynamoDB": "http://localhost:8000",
+		},
+		Region:              "us-east-1",
+		SkipCredsValidation: true,
+	}
+
+	_, awsConfig, diags := GetAwsConfig(context.Background(), c)
+	if diags.HasError() {
+		t.Fatalf("error in GetAwsConfig(): %v", diags)
+	}
+
+	if a, e := aws.ToString(dynamodb.NewFromConfig(awsConfig).Options().BaseEndpoint), "http://localhost:8000"; a != e {
+		t.Errorf("expected DynamoDB base endpoint %q, got %q", e, a)
+	}
+	if a, e := aws.ToString(s3.NewFromConfig(awsConfig).Options().BaseEndpoint), "http://localhost:4566"; a != e {
+		t.Errorf("expected S3 base endpoint %q, got %q", e, a)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package endpoints
+
+import (
+	"slices"
+)
+
+// EndpointVariant is a combination of endpoint variant tags.
+type EndpointVariant uint8
+
+const (
+	FIPSVariant EndpointVariant = 1 << iota
+	DualStackVariant
+)
+
+// Endpoint represents a service endpoint in a Region.
+type Endpoint struct {
+	id              string
+	hostname        string
+	variants        map[EndpointVariant]string
+	deprecated      bool
+	credentialScope CredentialScope
+}
+
+// ID returns the endpoint's identifier, usually a Region ID.
+func (e Endpoint) ID() string {
+	return e.id
+}
+
+// Hostname returns the endpoint's hostname.
+func (e Endpoint) Hostname() string {
+	return e.hostname
+}
+
+// Variants returns the endpoint's variants.
+func (e Endpoint) Variants() []EndpointVariant {
+	variants := make([]EndpointVariant, 0, len(e.variants))
+	for v := range e.variants {
+		variants = append(variants, v)
+	}
+	slices.Sort(variants)
+
+	return variants
+}
+
+// HasVariant returns whether the endpoint has the specified variant.
+func (e Endpoint) HasVariant(v EndpointVariant) bool {
+	_, ok := e.variants[v]
+
+	return ok
+}
+
+// VariantHostname returns the hostname of the endpoint's specified variant.
+func (e Endpoint) VariantHostname(v EndpointVariant) (string, bool) {
+	hostname, ok := e.variants[v]
+
+	return hostname, ok
+}
+
+// Deprecated returns whether the endpoint is deprecated, e.g. "fips-us-east-1".
+func (e Endpoint) Deprecated() bool {
+	return e.deprecated
+}
+
+// CredentialScope returns the endpoint's credential scope.
+func (e Endpoint) CredentialScope() CredentialScope {
+	return e.credentialScope
+}
+
+// SigningRegion returns the Region used to sign requests to the endpoint.
+func (e Endpoint) SigningRegion() string {
+	if e.credentialScope.region != "" {
+		return e.credentialScope.region
+	}
+
+	return e.id
+}
+
+// CredentialScope represents the overrides used when signing requests to an endpoint.
+type CredentialScope struct {
+	region  string
+	service string
+}
+
+// Region returns the signing Region override, if any.
+func (c CredentialScope) Region() string {
+	return c.region
+}
+
+// Service returns the signing name override, if any.
+func (c CredentialScope) Service() string {
+	return c.service
+}
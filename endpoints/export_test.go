@@ -0,0 +1,20 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package endpoints
+
+import (
+	"sync"
+)
+
+// ResetRegistry removes partitions added at runtime and forgets partitions loaded from the environment,
+// so that tests which modify the registry don't affect each other.
+func ResetRegistry() {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	registry.added = nil
+	registry.merged.Store(nil)
+	registry.envOnce = sync.Once{}
+	registry.envErr = nil
+}
@@ -1,6 +1,6 @@
 // Copyright (c) HashiCorp, Inc.
 // SPDX-License-Identifier: MPL-2.0
 
-//go:generate go run ../internal/generate/endpoints/main.go -- https://raw.githubusercontent.com/aws/aws-sdk-go-v2/main/codegen/smithy-aws-go-codegen/src/main/resources/software/amazon/smithy/aws/go/codegen/endpoints.json
+//go:generate go run ../internal/generate/endpoints/main.go -partitions-sha256 0caf9dfe139339c47a64e10e61f7ae436e7fb1c94353a4f862b1409d17b3545c -- https://raw.githubusercontent.com/aws/aws-sdk-go-v2/v1.42.1/codegen/smithy-aws-go-codegen/src/main/resources/software/amazon/smithy/aws/go/codegen/endpoints.json https://raw.githubusercontent.com/aws/aws-sdk-go-v2/v1.42.1/internal/endpoints/awsrulesfn/partitions.json
 
 package endpoints
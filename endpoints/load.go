@@ -0,0 +1,252 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package endpoints
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/hashicorp/aws-sdk-go-base/v2/internal/endpointsdoc"
+)
+
+// PartitionsFileEnvVar is the environment variable containing a list of partition definition files, separated by the
+// OS-specific path list separator, which are merged with the built-in partitions.
+const PartitionsFileEnvVar = "TF_AWS_PARTITIONS_FILE"
+
+var registry struct {
+	mu      sync.Mutex
+	added   map[string]Partition        // Partitions added at runtime, indexed by their ID.
+	merged  atomic.Pointer[[]Partition] // Built-in partitions merged with added partitions, computed on demand and never modified.
+	envOnce sync.Once
+	envErr  error
+}
+
+// LoadPartitions reads partition definitions from an AWS SDK endpoints or partitions JSON document.
+// The partitions can be merged with the built-in partitions using AddPartitions.
+func LoadPartitions(r io.Reader) ([]Partition, error) {
+	pds, err := endpointsdoc.Decode(r)
+	if err != nil {
+		return nil, err
+	}
+
+	ps := make([]Partition, 0, len(pds))
+	for _, pd := range pds {
+		p, err := newPartition(pd)
+		if err != nil {
+			return nil, err
+		}
+		ps = append(ps, p)
+	}
+
+	return ps, nil
+}
+
+// LoadPartitionsFile reads partition definitions from an AWS SDK endpoints or partitions JSON file.
+func LoadPartitionsFile(filename string) ([]Partition, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	ps, err := LoadPartitions(f)
+	if err != nil {
+		return nil, fmt.Errorf("loading partitions from %s: %w", filename, err)
+	}
+
+	return ps, nil
+}
+
+// newPartition returns a partition from a definition read from a partition definition file.
+func newPartition(pd endpointsdoc.Partition) (Partition, error) {
+	p := Partition{
+		id:        pd.ID,
+		name:      pd.Name,
+		dnsSuffix: pd.DNSSuffix,
+		// Service principals aren't included in partition definition files.
+		servicePrincipalDNSSuffix: defaultServicePrincipalDNSSuffix,
+		regions:                   make(map[string]Region, len(pd.Regions)),
+		services:                  make(map[string]Service, len(pd.Services)),
+	}
+
+	if o := pd.Outputs; o != nil {
+		p.dualStackDNSSuffix = o.DualStackDNSSuffix
+		p.implicitGlobalRegion = o.ImplicitGlobalRegion
+		p.supportsDualStack = o.SupportsDualStack
+		p.supportsFIPS = o.SupportsFIPS
+		p.hasOutputs = true
+	}
+
+	if pd.RegionRegex != "" {
+		regionRegex, err := regexp.Compile(pd.RegionRegex)
+		if err != nil {
+			return Partition{}, fmt.Errorf("partition (%s) Region regex: %w", p.id, err)
+		}
+		p.regionRegex = regionRegex
+	}
+
+	for id, rd := range pd.Regions {
+		p.regions[id] = Region{
+			id:          id,
+			description: rd.Description,
+			geography:   geographyForRegion(id),
+		}
+	}
+
+	for id, sd := range pd.Services {
+		s := Service{
+			id:                id,
+			partitionEndpoint: sd.PartitionEndpoint,
+			endpoints:         make(map[string]Endpoint, len(sd.Endpoints)),
+		}
+
+		for region, ed := range sd.Endpoints {
+			e := Endpoint{
+				id:         region,
+				hostname:   ed.Hostname,
+				deprecated: ed.Deprecated,
+				credentialScope: CredentialScope{
+					region:  ed.CredentialScope.Region,
+					service: ed.CredentialScope.Service,
+				},
+			}
+			for v, hostname := range ed.Variants {
+				if e.variants == nil {
+					e.variants = make(map[EndpointVariant]string, len(ed.Variants))
+				}
+				e.variants[EndpointVariant(v)] = hostname
+			}
+			s.endpoints[region] = e
+		}
+
+		p.services[id] = s
+	}
+
+	return p, nil
+}
+
+// AddPartitions merges partitions with the built-in partitions returned by DefaultPartitions.
+// A partition with the same ID as an existing partition is merged into it: regions and services replace those with the same ID
+// and other values replace existing values when set. Partitions with a new ID are added.
+func AddPartitions(ps ...Partition) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	if registry.added == nil {
+		registry.added = make(map[string]Partition, len(ps))
+	}
+	for _, p := range ps {
+		if existing, ok := registry.added[p.id]; ok {
+			p = mergePartition(existing, p)
+		}
+		registry.added[p.id] = p
+	}
+	registry.merged.Store(nil)
+}
+
+// LoadEnvironmentPartitions adds the partitions from the files named in the environment variable PartitionsFileEnvVar.
+// The files are read at most once; subsequent calls return the result of the first call.
+func LoadEnvironmentPartitions() error {
+	registry.envOnce.Do(addEnvironmentPartitions)
+
+	return registry.envErr
+}
+
+// addEnvironmentPartitions adds the partitions from the files named in the environment.
+// An error is logged and kept to be returned by LoadEnvironmentPartitions.
+func addEnvironmentPartitions() {
+	ps, err := loadEnvironmentPartitions()
+	if err != nil {
+		log.Printf("[WARN] Partitions named in %s not loaded: %s", PartitionsFileEnvVar, err)
+		registry.envErr = err
+		return
+	}
+
+	AddPartitions(ps...)
+}
+
+// loadEnvironmentPartitions reads the partition definition files named in the environment.
+func loadEnvironmentPartitions() ([]Partition, error) {
+	v := os.Getenv(PartitionsFileEnvVar)
+	if v == "" {
+		return nil, nil
+	}
+
+	var ps []Partition
+	for _, filename := range filepath.SplitList(v) {
+		if filename == "" {
+			continue
+		}
+		loaded, err := LoadPartitionsFile(filename)
+		if err != nil {
+			return nil, err
+		}
+		ps = append(ps, loaded...)
+	}
+
+	return ps, nil
+}
+
+// mergePartition merges partition definitions.
+// Values set in overlay replace those in base, and regions and services in overlay replace those with the same ID in base.
+// Service principal values are kept from base, since partition definition files don't include them.
+func mergePartition(base, overlay Partition) Partition {
+	p := base
+	p.regions = make(map[string]Region, len(base.regions)+len(overlay.regions))
+	p.services = make(map[string]Service, len(base.services)+len(overlay.services))
+
+	if overlay.name != "" {
+		p.name = overlay.name
+	}
+	if overlay.dnsSuffix != "" {
+		p.dnsSuffix = overlay.dnsSuffix
+	}
+	if overlay.regionRegex != nil {
+		p.regionRegex = overlay.regionRegex
+	}
+	if overlay.hasOutputs {
+		p.hasOutputs = true
+		p.dualStackDNSSuffix = overlay.dualStackDNSSuffix
+		p.implicitGlobalRegion = overlay.implicitGlobalRegion
+		p.supportsDualStack = overlay.supportsDualStack
+		p.supportsFIPS = overlay.supportsFIPS
+	}
+
+	for id, r := range base.regions {
+		p.regions[id] = r
+	}
+	// Region metadata not included in partition definition files is kept.
+	for id, r := range overlay.regions {
+		if existing, ok := p.regions[id]; ok {
+			existing.description = r.description
+			r = existing
+		}
+		p.regions[id] = r
+	}
+	for _, m := range []map[string]Service{base.services, overlay.services} {
+		for id, s := range m {
+			p.services[id] = s
+		}
+	}
+
+	return p
+}
+
+// sortedPartitionIDs returns the IDs of the partitions in a map in sorted order.
+func sortedPartitionIDs(m map[string]Partition) []string {
+	ids := make([]string, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
@@ -0,0 +1,377 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package endpoints_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/aws-sdk-go-base/v2/endpoints"
+)
+
+const testEndpointsDocument = `{
+  "partitions": [
+    {
+      "partition": "aws-example",
+      "partitionName": "AWS Example",
+      "dnsSuffix": "example.com",
+      "regionRegex": "^ex\\-\\w+\\-\\d+$",
+      "defaults": {
+        "hostname": "{service}.{region}.{dnsSuffix}",
+        "variants": [
+          {"hostname": "{service}-fips.{region}.{dnsSuffix}", "tags": ["fips"]}
+        ]
+      },
+      "regions": {
+        "ex-north-1": {"description": "Example (North)"},
+        "ex-south-1": {"description": "Example (South)"}
+      },
+      "services": {
+        "dynamodb": {
+          "defaults": {
+            "variants": [
+              {"hostname": "{service}-fips.{region}.{dnsSuffix}", "tags": ["fips"]}
+            ]
+          },
+          "endpoints": {
+            "ex-north-1": {},
+            "ex-south-1": {"deprecated": true, "credentialScope": {"region": "ex-north-1"}}
+          }
+        },
+        "iam": {
+          "isRegionalized": false,
+          "partitionEndpoint": "aws-example-global",
+          "endpoints": {
+            "aws-example-global": {"hostname": "iam.example.com"}
+          }
+        }
+      }
+    }
+  ]
+}`
+
+const testPartitionsDocument = `{
+  "partitions": [
+    {
+      "id": "aws-example",
+      "outputs": {
+        "dnsSuffix": "example.com",
+        "dualStackDnsSuffix": "api.example.com",
+        "implicitGlobalRegion": "ex-north-1",
+        "supportsDualStack": true,
+        "supportsFIPS": true
+      },
+      "regionRegex": "^ex\\-\\w+\\-\\d+$",
+      "regions": {
+        "aws-example-global": {"description": "AWS Example global region"},
+        "ex-west-1": {"description": "Example (West)"}
+      }
+    }
+  ]
+}`
+
+func TestLoadPartitionsEndpointsDocument(t *testing.T) {
+	t.Parallel()
+
+	ps, err := endpoints.LoadPartitions(strings.NewReader(testEndpointsDocument))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(ps) != 1 {
+		t.Fatalf("expected 1 partition, got %d", len(ps))
+	}
+	p := ps[0]
+
+	if a, e := p.ID(), "aws-example"; a != e {
+		t.Errorf("expected ID %q, got %q", e, a)
+	}
+	if a, e := p.Name(), "AWS Example"; a != e {
+		t.Errorf("expected name %q, got %q", e, a)
+	}
+	if a, e := p.ServicePrincipal("ec2"), "ec2.amazonaws.com"; a != e {
+		t.Errorf("expected service principal %q, got %q", e, a)
+	}
+	if !p.RegionRegex().MatchString("ex-east-1") {
+		t.Errorf("expected Region regex to match %q", "ex-east-1")
+	}
+	if a, e := len(p.Regions()), 2; a != e {
+		t.Errorf("expected %d Regions, got %d", e, a)
+	}
+
+	endpoint, ok := p.ServiceEndpoint("dynamodb", "ex-north-1")
+	if !ok {
+		t.Fatalf("expected endpoint for %q in %q", "dynamodb", "ex-north-1")
+	}
+	if a, e := endpoint.Hostname(), "dynamodb.ex-north-1.example.com"; a != e {
+		t.Errorf("expected hostname %q, got %q", e, a)
+	}
+	if hostname, ok := endpoint.VariantHostname(endpoints.FIPSVariant); !ok {
+		t.Errorf("expected FIPS variant")
+	} else if a, e := hostname, "dynamodb-fips.ex-north-1.example.com"; a != e {
+		t.Errorf("expected FIPS hostname %q, got %q", e, a)
+	}
+
+	endpoint, ok = p.ServiceEndpoint("dynamodb", "ex-south-1")
+	if !ok {
+		t.Fatalf("expected endpoint for %q in %q", "dynamodb", "ex-south-1")
+	}
+	if !endpoint.Deprecated() {
+		t.Errorf("expected endpoint to be deprecated")
+	}
+	if a, e := endpoint.SigningRegion(), "ex-north-1"; a != e {
+		t.Errorf("expected signing Region %q, got %q", e, a)
+	}
+
+	endpoint, ok = p.ServiceEndpoint("iam", "ex-south-1")
+	if !ok {
+		t.Fatalf("expected endpoint for %q in %q", "iam", "ex-south-1")
+	}
+	if a, e := endpoint.Hostname(), "iam.example.com"; a != e {
+		t.Errorf("expected hostname %q, got %q", e, a)
+	}
+	if endpoint.HasVariant(endpoints.FIPSVariant) {
+		t.Errorf("expected no FIPS variant from partition defaults")
+	}
+}
+
+func TestLoadPartitionsPartitionsDocument(t *testing.T) {
+	t.Parallel()
+
+	ps, err := endpoints.LoadPartitions(strings.NewReader(testPartitionsDocument))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(ps) != 1 {
+		t.Fatalf("expected 1 partition, got %d", len(ps))
+	}
+	p := ps[0]
+
+	if a, e := p.DNSSuffix(), "example.com"; a != e {
+		t.Errorf("expected DNS suffix %q, got %q", e, a)
+	}
+	if a, e := p.DualStackDNSSuffix(), "api.example.com"; a != e {
+		t.Errorf("expected dual-stack DNS suffix %q, got %q", e, a)
+	}
+	if a, e := p.ImplicitGlobalRegion(), "ex-north-1"; a != e {
+		t.Errorf("expected implicit global Region %q, got %q", e, a)
+	}
+	if !p.SupportsDualStack() || !p.SupportsFIPS() {
+		t.Errorf("expected dual-stack and FIPS support")
+	}
+	if _, ok := p.Regions()["aws-example-global"]; ok {
+		t.Errorf("expected no Region %q", "aws-example-global")
+	}
+	if a, e := len(p.Regions()), 1; a != e {
+		t.Errorf("expected %d Regions, got %d", e, a)
+	}
+}
+
+func TestLoadPartitionsInvalid(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]string{
+		"not JSON":             `partitions`,
+		"missing ID":           `{"partitions": [{"dnsSuffix": "example.com"}]}`,
+		"invalid Region regex": `{"partitions": [{"partition": "aws-example", "regionRegex": "^ex-("}]}`,
+	}
+
+	for name, document := range testcases {
+		document := document
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := endpoints.LoadPartitions(strings.NewReader(document)); err == nil {
+				t.Errorf("expected error, got none")
+			}
+		})
+	}
+}
+
+func TestLoadPartitionsFile(t *testing.T) {
+	t.Parallel()
+
+	filename := filepath.Join(t.TempDir(), "endpoints.json")
+	if err := os.WriteFile(filename, []byte(testEndpointsDocument), 0600); err != nil {
+		t.Fatalf("writing file: %s", err)
+	}
+
+	ps, err := endpoints.LoadPartitionsFile(filename)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(ps) != 1 {
+		t.Errorf("expected 1 partition, got %d", len(ps))
+	}
+
+	if _, err := endpoints.LoadPartitionsFile(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Errorf("expected error for missing file, got none")
+	}
+}
+
+// resetPartitions restores the partitions registry when a test which modifies it completes.
+// Tests which modify the registry must not run in parallel with other tests.
+func resetPartitions(t *testing.T) {
+	t.Helper()
+
+	endpoints.ResetRegistry()
+	t.Cleanup(endpoints.ResetRegistry)
+}
+
+func TestAddPartitions(t *testing.T) {
+	resetPartitions(t)
+
+	var ps []endpoints.Partition
+	for _, document := range []string{testEndpointsDocument, testPartitionsDocument} {
+		loaded, err := endpoints.LoadPartitions(strings.NewReader(document))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		ps = append(ps, loaded...)
+	}
+
+	endpoints.AddPartitions(ps...)
+
+	p, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), "ex-west-1")
+	if !ok {
+		t.Fatalf("expected partition for %q", "ex-west-1")
+	}
+	if a, e := p.ID(), "aws-example"; a != e {
+		t.Errorf("expected partition ID %q, got %q", e, a)
+	}
+	// Regions are merged.
+	if a, e := len(p.Regions()), 3; a != e {
+		t.Errorf("expected %d Regions, got %d", e, a)
+	}
+	// Values from the endpoints document are kept.
+	if a, e := p.Name(), "AWS Example"; a != e {
+		t.Errorf("expected name %q, got %q", e, a)
+	}
+	if _, ok := p.ServiceEndpoint("dynamodb", "ex-north-1"); !ok {
+		t.Errorf("expected endpoint for %q in %q", "dynamodb", "ex-north-1")
+	}
+	// Values from the partitions document are added.
+	if !p.SupportsFIPS() {
+		t.Errorf("expected FIPS support")
+	}
+	// Partition-wide pseudo Regions are not added.
+	if _, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), "aws-example-global"); ok {
+		t.Errorf("expected no partition for %q", "aws-example-global")
+	}
+
+	// Built-in partitions are unchanged.
+	p, ok = endpoints.PartitionForRegion(endpoints.DefaultPartitions(), "us-east-1")
+	if !ok {
+		t.Fatalf("expected partition for %q", "us-east-1")
+	}
+	if a, e := p.ID(), "aws"; a != e {
+		t.Errorf("expected partition ID %q, got %q", e, a)
+	}
+}
+
+func TestDefaultPartitionsSnapshot(t *testing.T) {
+	resetPartitions(t)
+
+	ps := endpoints.DefaultPartitions()
+	// The merged partitions are computed once and shared.
+	if a, e := &endpoints.DefaultPartitions()[0], &ps[0]; a != e {
+		t.Errorf("expected the same partitions to be returned")
+	}
+
+	loaded, err := endpoints.LoadPartitions(strings.NewReader(testEndpointsDocument))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	endpoints.AddPartitions(loaded...)
+
+	if a, e := len(endpoints.DefaultPartitions()), len(ps)+1; a != e {
+		t.Errorf("expected %d partitions, got %d", e, a)
+	}
+	// Previously returned partitions are unchanged.
+	if _, ok := endpoints.PartitionForRegion(ps, "ex-north-1"); ok {
+		t.Errorf("expected no partition for %q in previously returned partitions", "ex-north-1")
+	}
+}
+
+func TestAddPartitionsServicePrincipals(t *testing.T) {
+	resetPartitions(t)
+
+	ps, err := endpoints.LoadPartitions(strings.NewReader(`{
+  "partitions": [
+    {
+      "id": "aws-cn",
+      "regions": {
+        "cn-east-1": {"description": "China (East)"}
+      }
+    }
+  ]
+}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	endpoints.AddPartitions(ps...)
+
+	p, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), "cn-east-1")
+	if !ok {
+		t.Fatalf("expected partition for %q", "cn-east-1")
+	}
+	// Built-in service principal values are kept.
+	if a, e := p.ServicePrincipal("lambda"), "lambda.amazonaws.com"; a != e {
+		t.Errorf("expected service principal %q, got %q", e, a)
+	}
+	if a, e := p.ServicePrincipal("logs"), "logs.amazonaws.com.cn"; a != e {
+		t.Errorf("expected service principal %q, got %q", e, a)
+	}
+}
+
+func TestLoadEnvironmentPartitions(t *testing.T) {
+	dir := t.TempDir()
+	endpointsFile := filepath.Join(dir, "endpoints.json")
+	if err := os.WriteFile(endpointsFile, []byte(testEndpointsDocument), 0600); err != nil {
+		t.Fatalf("writing file: %s", err)
+	}
+	partitionsFile := filepath.Join(dir, "partitions.json")
+	if err := os.WriteFile(partitionsFile, []byte(testPartitionsDocument), 0600); err != nil {
+		t.Fatalf("writing file: %s", err)
+	}
+
+	resetPartitions(t)
+	t.Setenv(endpoints.PartitionsFileEnvVar, strings.Join([]string{endpointsFile, partitionsFile}, string(os.PathListSeparator)))
+
+	if err := endpoints.LoadEnvironmentPartitions(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	p, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), "ex-west-1")
+	if !ok {
+		t.Fatalf("expected partition for %q", "ex-west-1")
+	}
+	if a, e := p.ID(), "aws-example"; a != e {
+		t.Errorf("expected partition ID %q, got %q", e, a)
+	}
+	if a, e := len(p.Regions()), 3; a != e {
+		t.Errorf("expected %d Regions, got %d", e, a)
+	}
+}
+
+func TestLoadEnvironmentPartitionsInvalid(t *testing.T) {
+	resetPartitions(t)
+	t.Setenv(endpoints.PartitionsFileEnvVar, filepath.Join(t.TempDir(), "missing.json"))
+
+	// The error is reported when the partitions are first used and on every subsequent call.
+	ps := endpoints.DefaultPartitions()
+	if _, ok := endpoints.PartitionForRegion(ps, "us-east-1"); !ok {
+		t.Errorf("expected built-in partitions")
+	}
+	for i := 0; i < 2; i++ {
+		if err := endpoints.LoadEnvironmentPartitions(); err == nil {
+			t.Errorf("call %d: expected error, got none", i+1)
+		}
+	}
+}
@@ -4,19 +4,30 @@
 package endpoints
 
 import (
+	"fmt"
 	"maps"
 	"regexp"
 )
 
+// defaultServicePrincipalDNSSuffix is the base domain name of service principals in partitions loaded at runtime.
+const defaultServicePrincipalDNSSuffix = "amazonaws.com"
+
 // Partition represents an AWS partition.
 // See https://docs.aws.amazon.com/whitepapers/latest/aws-fault-isolation-boundaries/partitions.html.
 type Partition struct {
-	id          string
-	name        string
-	dnsSuffix   string
-	regionRegex *regexp.Regexp
-	regions     map[string]Region
-	services    map[string]Service
+	id                          string
+	name                        string
+	dnsSuffix                   string
+	dualStackDNSSuffix          string
+	implicitGlobalRegion        string
+	servicePrincipalDNSSuffix   string
+	servicePrincipalDNSSuffixes map[string]string // Per-service overrides of servicePrincipalDNSSuffix, indexed by service.
+	supportsDualStack           bool
+	supportsFIPS                bool
+	hasOutputs                  bool // Whether the partition metadata values were set, see LoadPartitions.
+	regionRegex                 *regexp.Regexp
+	regions                     map[string]Region
+	services                    map[string]Service
 }
 
 // ID returns the identifier of the partition.
@@ -34,6 +45,49 @@ func (p Partition) DNSSuffix() string {
 	return p.dnsSuffix
 }
 
+// DualStackDNSSuffix returns the base domain name of the partition's dual-stack endpoints.
+func (p Partition) DualStackDNSSuffix() string {
+	return p.dualStackDNSSuffix
+}
+
+// ImplicitGlobalRegion returns the Region used for global services in the partition, e.g. "us-east-1".
+func (p Partition) ImplicitGlobalRegion() string {
+	return p.implicitGlobalRegion
+}
+
+// ServicePrincipalDNSSuffix returns the base domain name of service principals in the partition, e.g. "amazonaws.com".
+// Some services' principals use a different domain name, see ServicePrincipal.
+func (p Partition) ServicePrincipalDNSSuffix() string {
+	return p.servicePrincipalDNSSuffix
+}
+
+// SupportsDualStack returns whether the partition supports dual-stack endpoints.
+func (p Partition) SupportsDualStack() bool {
+	return p.supportsDualStack
+}
+
+// SupportsFIPS returns whether the partition supports FIPS endpoints.
+func (p Partition) SupportsFIPS() bool {
+	return p.supportsFIPS
+}
+
+// ARN returns an Amazon Resource Name in the partition.
+// The Region and account ID are empty for some services' resources, e.g. "arn:aws:s3:::bucket".
+func (p Partition) ARN(service, regionID, accountID, resource string) string {
+	return fmt.Sprintf("arn:%s:%s:%s:%s:%s", p.id, service, regionID, accountID, resource)
+}
+
+// ServicePrincipal returns the IAM service principal for a service in the partition, e.g. "ec2.amazonaws.com".
+// Some services' principals use the partition's DNS suffix instead, e.g. "logs.amazonaws.com.cn".
+func (p Partition) ServicePrincipal(service string) string {
+	dnsSuffix := p.servicePrincipalDNSSuffix
+	if v, ok := p.servicePrincipalDNSSuffixes[service]; ok {
+		dnsSuffix = v
+	}
+
+	return fmt.Sprintf("%s.%s", service, dnsSuffix)
+}
+
 // RegionRegex return the regular expression that matches Region IDs for the partition.
 func (p Partition) RegionRegex() *regexp.Regexp {
 	return p.regionRegex
@@ -44,29 +98,181 @@ func (p Partition) Regions() map[string]Region {
 	return maps.Clone(p.regions)
 }
 
+// OptInRegions returns a map of the Regions in the partition which must be enabled for an account before use, indexed by their ID.
+// An account's opt-in status for each Region can be checked using the EC2 DescribeRegions API.
+func (p Partition) OptInRegions() map[string]Region {
+	regions := make(map[string]Region)
+
+	for id, r := range p.regions {
+		if r.optInRequired {
+			regions[id] = r
+		}
+	}
+
+	return regions
+}
+
 // Services returns a map of service endpoints for the partition, indexed by their ID.
 func (p Partition) Services() map[string]Service {
 	return maps.Clone(p.services)
 }
 
+// Service returns the service endpoint with the specified ID in the partition.
+func (p Partition) Service(id string) (Service, bool) {
+	s, ok := p.services[id]
+
+	return s, ok
+}
+
+// ServiceEndpoint returns the endpoint used for the specified service in the specified Region.
+func (p Partition) ServiceEndpoint(serviceID, regionID string) (Endpoint, bool) {
+	s, ok := p.services[serviceID]
+	if !ok {
+		return Endpoint{}, false
+	}
+
+	return s.EndpointForRegion(regionID)
+}
+
+// IsServiceAvailableIn returns whether the specified service has an endpoint used in the specified Region.
+// ok is false if availability is unknown because the partition has no service endpoint data for the Region,
+// e.g. the Region was launched after the endpoint data was last updated.
+// Global services are available in every Region of the partition.
+func (p Partition) IsServiceAvailableIn(serviceID, regionID string) (available, ok bool) {
+	s, found := p.services[serviceID]
+
+	if !p.hasServiceEndpoints(regionID) {
+		if _, isRegion := p.regions[regionID]; isRegion && found && s.IsGlobal() {
+			return true, true
+		}
+		return false, false
+	}
+
+	if !found {
+		return false, true
+	}
+
+	_, available = s.EndpointForRegion(regionID)
+
+	return available, true
+}
+
+// ServicesInRegion returns a map of the services available in the specified Region, indexed by their ID.
+// ok is false if the partition has no service endpoint data for the Region, in which case only global services are returned.
+func (p Partition) ServicesInRegion(regionID string) (services map[string]Service, ok bool) {
+	services = make(map[string]Service)
+	ok = p.hasServiceEndpoints(regionID)
+	_, isRegion := p.regions[regionID]
+
+	for id, s := range p.services {
+		if ok {
+			if _, found := s.EndpointForRegion(regionID); found {
+				services[id] = s
+			}
+		} else if isRegion && s.IsGlobal() {
+			services[id] = s
+		}
+	}
+
+	return services, ok
+}
+
+// hasServiceEndpoints returns whether any service in the partition has an endpoint for the specified Region.
+func (p Partition) hasServiceEndpoints(regionID string) bool {
+	for _, s := range p.services {
+		if _, ok := s.endpoints[regionID]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
 // DefaultPartitions returns a list of the partitions.
+// The built-in partitions are merged with any added using AddPartitions or named in the environment, see PartitionsFileEnvVar.
+// If the partitions named in the environment cannot be loaded they are not included;
+// the error is logged and returned by every call to LoadEnvironmentPartitions.
+// The returned list is shared by all callers until partitions are next added, and must not be modified.
 func DefaultPartitions() []Partition {
-	ps := make([]Partition, 0, len(partitions))
+	registry.envOnce.Do(addEnvironmentPartitions)
+
+	if merged := registry.merged.Load(); merged != nil {
+		return *merged
+	}
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	if merged := registry.merged.Load(); merged != nil {
+		return *merged
+	}
+
+	m := make(map[string]Partition, len(partitions)+len(registry.added))
+	for id, p := range partitions {
+		m[id] = p
+	}
+	for id, p := range registry.added {
+		if base, ok := m[id]; ok {
+			p = mergePartition(base, p)
+		}
+		m[id] = p
+	}
 
-	for _, p := range partitions {
-		ps = append(ps, p)
+	merged := make([]Partition, 0, len(m))
+	for _, id := range sortedPartitionIDs(m) {
+		merged = append(merged, m[id])
 	}
+	registry.merged.Store(&merged)
+
+	return merged
+}
+
+// PartitionMatchKind describes how a Region was matched to a partition.
+type PartitionMatchKind int
+
+const (
+	// PartitionMatchKindNone indicates that no partition matched the Region.
+	PartitionMatchKindNone PartitionMatchKind = iota
+	// PartitionMatchKindKnownRegion indicates that the Region is one of the partition's known Regions.
+	PartitionMatchKindKnownRegion
+	// PartitionMatchKindRegionRegex indicates that the Region is not a known Region but matches the partition's Region regex,
+	// e.g. a Region launched since the partition data was generated.
+	PartitionMatchKindRegionRegex
+)
 
-	return ps
+func (k PartitionMatchKind) String() string {
+	switch k {
+	case PartitionMatchKindKnownRegion:
+		return "known Region"
+	case PartitionMatchKindRegionRegex:
+		return "Region regex"
+	default:
+		return "none"
+	}
 }
 
 // PartitionForRegion returns the first partition which includes the specific Region.
+// A partition which includes the Region as a known Region is preferred to one whose Region regex matches it.
 func PartitionForRegion(ps []Partition, regionID string) (Partition, bool) {
+	p, kind := MatchPartitionForRegion(ps, regionID)
+
+	return p, kind != PartitionMatchKindNone
+}
+
+// MatchPartitionForRegion returns the first partition which includes the specific Region and how the Region was matched.
+// Known Regions are matched in all partitions before Region regexes are tried.
+func MatchPartitionForRegion(ps []Partition, regionID string) (Partition, PartitionMatchKind) {
+	for _, p := range ps {
+		if _, ok := p.regions[regionID]; ok {
+			return p, PartitionMatchKindKnownRegion
+		}
+	}
+
 	for _, p := range ps {
-		if _, ok := p.regions[regionID]; ok || p.regionRegex.MatchString(regionID) {
-			return p, true
+		if p.regionRegex != nil && p.regionRegex.MatchString(regionID) {
+			return p, PartitionMatchKindRegionRegex
 		}
 	}
 
-	return Partition{}, false
+	return Partition{}, PartitionMatchKindNone
 }
@@ -55,6 +55,47 @@ func TestPartitionForRegion(t *testing.T) {
 	}
 }
 
+func TestMatchPartitionForRegion(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]struct {
+		expectedKind endpoints.PartitionMatchKind
+		expectedID   string
+	}{
+		"us-east-1": {
+			expectedKind: endpoints.PartitionMatchKindKnownRegion,
+			expectedID:   "aws",
+		},
+		"us-gov-west-1": {
+			expectedKind: endpoints.PartitionMatchKindKnownRegion,
+			expectedID:   "aws-us-gov",
+		},
+		"us-east-17": {
+			expectedKind: endpoints.PartitionMatchKindRegionRegex,
+			expectedID:   "aws",
+		},
+		"cn-south-1": {
+			expectedKind: endpoints.PartitionMatchKindRegionRegex,
+			expectedID:   "aws-cn",
+		},
+		"not-found": {
+			expectedKind: endpoints.PartitionMatchKindNone,
+		},
+	}
+
+	ps := endpoints.DefaultPartitions()
+	for region, testcase := range testcases {
+		gotPartition, gotKind := endpoints.MatchPartitionForRegion(ps, region)
+
+		if gotKind != testcase.expectedKind {
+			t.Errorf("expected match kind %q for Region %q, got %q", testcase.expectedKind, region, gotKind)
+		}
+		if gotPartition.ID() != testcase.expectedID {
+			t.Errorf("expected PartitionID %q for Region %q, got %q", testcase.expectedID, region, gotPartition.ID())
+		}
+	}
+}
+
 func TestPartitionRegions(t *testing.T) {
 	t.Parallel()
 
@@ -108,3 +149,183 @@ func TestPartitionServices(t *testing.T) {
 		}
 	}
 }
+
+func TestPartitionMetadata(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]struct {
+		expectedDualStackDNSSuffix   string
+		expectedImplicitGlobalRegion string
+		expectedSupportsDualStack    bool
+		expectedSupportsFIPS         bool
+	}{
+		"us-east-1": {
+			expectedDualStackDNSSuffix:   "api.aws",
+			expectedImplicitGlobalRegion: "us-east-1",
+			expectedSupportsDualStack:    true,
+			expectedSupportsFIPS:         true,
+		},
+		"cn-north-1": {
+			expectedDualStackDNSSuffix:   "api.amazonwebservices.com.cn",
+			expectedImplicitGlobalRegion: "cn-northwest-1",
+			expectedSupportsDualStack:    true,
+			expectedSupportsFIPS:         true,
+		},
+		"us-iso-east-1": {
+			expectedDualStackDNSSuffix:   "api.aws.ic.gov",
+			expectedImplicitGlobalRegion: "us-iso-east-1",
+			expectedSupportsDualStack:    true,
+			expectedSupportsFIPS:         true,
+		},
+	}
+
+	ps := endpoints.DefaultPartitions()
+	for region, testcase := range testcases {
+		p, ok := endpoints.PartitionForRegion(ps, region)
+		if !ok {
+			t.Fatalf("expected partition for Region %q", region)
+		}
+
+		if got, want := p.DualStackDNSSuffix(), testcase.expectedDualStackDNSSuffix; got != want {
+			t.Errorf("expected DualStackDNSSuffix %q for Region %q, got %q", want, region, got)
+		}
+		if got, want := p.ImplicitGlobalRegion(), testcase.expectedImplicitGlobalRegion; got != want {
+			t.Errorf("expected ImplicitGlobalRegion %q for Region %q, got %q", want, region, got)
+		}
+		if got, want := p.SupportsDualStack(), testcase.expectedSupportsDualStack; got != want {
+			t.Errorf("expected SupportsDualStack %t for Region %q, got %t", want, region, got)
+		}
+		if got, want := p.SupportsFIPS(), testcase.expectedSupportsFIPS; got != want {
+			t.Errorf("expected SupportsFIPS %t for Region %q, got %t", want, region, got)
+		}
+	}
+}
+
+func TestPartitionARNAndServicePrincipal(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]struct {
+		region                   string
+		service                  string
+		expectedARN              string
+		expectedServicePrincipal string
+	}{
+		"aws": {
+			region:                   "us-east-1",
+			service:                  "ec2",
+			expectedARN:              "arn:aws:iam::123456789012:role/example",
+			expectedServicePrincipal: "ec2.amazonaws.com",
+		},
+		"aws-cn": {
+			region:                   "cn-north-1",
+			service:                  "lambda",
+			expectedARN:              "arn:aws-cn:iam::123456789012:role/example",
+			expectedServicePrincipal: "lambda.amazonaws.com",
+		},
+		"aws-cn partition DNS suffix": {
+			region:                   "cn-northwest-1",
+			service:                  "logs",
+			expectedARN:              "arn:aws-cn:iam::123456789012:role/example",
+			expectedServicePrincipal: "logs.amazonaws.com.cn",
+		},
+		"aws-us-gov": {
+			region:                   "us-gov-west-1",
+			service:                  "ec2",
+			expectedARN:              "arn:aws-us-gov:iam::123456789012:role/example",
+			expectedServicePrincipal: "ec2.amazonaws.com",
+		},
+		"aws-iso": {
+			region:                   "us-iso-east-1",
+			service:                  "ec2",
+			expectedARN:              "arn:aws-iso:iam::123456789012:role/example",
+			expectedServicePrincipal: "ec2.amazonaws.com",
+		},
+		"aws-iso partition DNS suffix": {
+			region:                   "us-iso-east-1",
+			service:                  "config",
+			expectedARN:              "arn:aws-iso:iam::123456789012:role/example",
+			expectedServicePrincipal: "config.c2s.ic.gov",
+		},
+		"aws-iso-b": {
+			region:                   "us-isob-east-1",
+			service:                  "ec2",
+			expectedARN:              "arn:aws-iso-b:iam::123456789012:role/example",
+			expectedServicePrincipal: "ec2.amazonaws.com",
+		},
+		"aws-iso-b partition DNS suffix": {
+			region:                   "us-isob-east-1",
+			service:                  "logs",
+			expectedARN:              "arn:aws-iso-b:iam::123456789012:role/example",
+			expectedServicePrincipal: "logs.sc2s.sgov.gov",
+		},
+		"aws-iso-e": {
+			region:                   "eu-isoe-west-1",
+			service:                  "logs",
+			expectedARN:              "arn:aws-iso-e:iam::123456789012:role/example",
+			expectedServicePrincipal: "logs.amazonaws.com",
+		},
+		"aws-iso-f": {
+			region:                   "us-isof-south-1",
+			service:                  "ec2",
+			expectedARN:              "arn:aws-iso-f:iam::123456789012:role/example",
+			expectedServicePrincipal: "ec2.amazonaws.com",
+		},
+	}
+
+	ps := endpoints.DefaultPartitions()
+	for name, testcase := range testcases {
+		testcase := testcase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			p, ok := endpoints.PartitionForRegion(ps, testcase.region)
+			if !ok {
+				t.Fatalf("expected partition for Region %q", testcase.region)
+			}
+
+			if got, want := p.ARN("iam", "", "123456789012", "role/example"), testcase.expectedARN; got != want {
+				t.Errorf("expected ARN %q, got %q", want, got)
+			}
+			if got, want := p.ServicePrincipalDNSSuffix(), "amazonaws.com"; got != want {
+				t.Errorf("expected service principal DNS suffix %q, got %q", want, got)
+			}
+			if got, want := p.ServicePrincipal(testcase.service), testcase.expectedServicePrincipal; got != want {
+				t.Errorf("expected service principal %q, got %q", want, got)
+			}
+		})
+	}
+}
+
+func TestPartitionOptInRegions(t *testing.T) {
+	t.Parallel()
+
+	ps := endpoints.DefaultPartitions()
+
+	p, ok := endpoints.PartitionForRegion(ps, "us-east-1")
+	if !ok {
+		t.Fatalf("expected partition for Region %q", "us-east-1")
+	}
+
+	regions := p.OptInRegions()
+	if _, ok := regions["af-south-1"]; !ok {
+		t.Errorf("expected Region %q in opt-in Regions", "af-south-1")
+	}
+	if _, ok := regions["us-east-1"]; ok {
+		t.Errorf("expected Region %q not in opt-in Regions", "us-east-1")
+	}
+	for id, r := range regions {
+		if !r.OptInRequired() {
+			t.Errorf("expected Region %q to require opt-in", id)
+		}
+	}
+
+	p, ok = endpoints.PartitionForRegion(ps, "us-gov-west-1")
+	if !ok {
+		t.Fatalf("expected partition for Region %q", "us-gov-west-1")
+	}
+
+	if regions := p.OptInRegions(); len(regions) != 0 {
+		t.Errorf("expected no opt-in Regions in partition %q, got %d", p.ID(), len(regions))
+	}
+}
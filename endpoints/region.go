@@ -3,11 +3,31 @@
 
 package endpoints
 
+import (
+	"strings"
+)
+
+// Geography is the geographic area in which an AWS Region is located.
+type Geography string
+
+const (
+	GeographyUnknown      Geography = ""
+	GeographyAfrica       Geography = "Africa"
+	GeographyAsiaPacific  Geography = "Asia Pacific"
+	GeographyEurope       Geography = "Europe"
+	GeographyMiddleEast   Geography = "Middle East"
+	GeographyNorthAmerica Geography = "North America"
+	GeographySouthAmerica Geography = "South America"
+)
+
 // Region represents an AWS Region.
 // See https://docs.aws.amazon.com/whitepapers/latest/aws-fault-isolation-boundaries/regions.html.
 type Region struct {
-	id          string
-	description string
+	id              string
+	description     string
+	geography       Geography
+	optInRequired   bool
+	localZoneParent bool
 }
 
 // ID returns the Region's identifier.
@@ -19,3 +39,42 @@ func (r Region) ID() string {
 func (r Region) Description() string {
 	return r.description
 }
+
+// Geography returns the geographic area in which the Region is located.
+func (r Region) Geography() Geography {
+	return r.geography
+}
+
+// OptInRequired returns whether the Region must be enabled for an account before use.
+// See https://docs.aws.amazon.com/accounts/latest/reference/manage-acct-regions.html.
+func (r Region) OptInRequired() bool {
+	return r.optInRequired
+}
+
+// LocalZoneParent returns whether the Region is the parent Region of one or more Local Zones.
+// See https://docs.aws.amazon.com/local-zones/latest/ug/available-local-zones.html.
+func (r Region) LocalZoneParent() bool {
+	return r.localZoneParent
+}
+
+// geographyForRegion returns the geographic area of a Region based on the prefix of its ID, e.g. "eu" in "eu-west-1".
+func geographyForRegion(id string) Geography {
+	prefix, _, _ := strings.Cut(id, "-")
+
+	switch prefix {
+	case "af":
+		return GeographyAfrica
+	case "ap", "cn":
+		return GeographyAsiaPacific
+	case "eu":
+		return GeographyEurope
+	case "il", "me":
+		return GeographyMiddleEast
+	case "ca", "mx", "us":
+		return GeographyNorthAmerica
+	case "sa":
+		return GeographySouthAmerica
+	default:
+		return GeographyUnknown
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package endpoints_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/aws-sdk-go-base/v2/endpoints"
+)
+
+func TestRegionMetadata(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]struct {
+		expectedGeography       endpoints.Geography
+		expectedOptInRequired   bool
+		expectedLocalZoneParent bool
+	}{
+		"us-east-1": {
+			expectedGeography:       endpoints.GeographyNorthAmerica,
+			expectedLocalZoneParent: true,
+		},
+		"af-south-1": {
+			expectedGeography:       endpoints.GeographyAfrica,
+			expectedOptInRequired:   true,
+			expectedLocalZoneParent: true,
+		},
+		"eu-south-2": {
+			expectedGeography:     endpoints.GeographyEurope,
+			expectedOptInRequired: true,
+		},
+		"il-central-1": {
+			expectedGeography:     endpoints.GeographyMiddleEast,
+			expectedOptInRequired: true,
+		},
+		"sa-east-1": {
+			expectedGeography: endpoints.GeographySouthAmerica,
+		},
+		"cn-north-1": {
+			expectedGeography: endpoints.GeographyAsiaPacific,
+		},
+		"us-gov-west-1": {
+			expectedGeography: endpoints.GeographyNorthAmerica,
+		},
+	}
+
+	ps := endpoints.DefaultPartitions()
+	for id, testcase := range testcases {
+		p, ok := endpoints.PartitionForRegion(ps, id)
+		if !ok {
+			t.Fatalf("expected partition for Region %q", id)
+		}
+
+		r, ok := p.Regions()[id]
+		if !ok {
+			t.Fatalf("expected Region %q in partition %q", id, p.ID())
+		}
+
+		if got, want := r.Geography(), testcase.expectedGeography; got != want {
+			t.Errorf("expected Geography %q for Region %q, got %q", want, id, got)
+		}
+		if got, want := r.OptInRequired(), testcase.expectedOptInRequired; got != want {
+			t.Errorf("expected OptInRequired %t for Region %q, got %t", want, id, got)
+		}
+		if got, want := r.LocalZoneParent(), testcase.expectedLocalZoneParent; got != want {
+			t.Errorf("expected LocalZoneParent %t for Region %q, got %t", want, id, got)
+		}
+	}
+}
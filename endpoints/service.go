@@ -3,12 +3,60 @@
 
 package endpoints
 
+import (
+	"maps"
+)
+
 // Service represents an AWS service endpoint.
 type Service struct {
-	id string
+	id                string
+	partitionEndpoint string
+	endpoints         map[string]Endpoint
 }
 
 // ID returns the service endpoint's identifier.
 func (s Service) ID() string {
 	return s.id
 }
+
+// PartitionEndpoint returns the ID of the endpoint used in all Regions for a non-regionalized service, e.g. "aws-global".
+func (s Service) PartitionEndpoint() string {
+	return s.partitionEndpoint
+}
+
+// IsGlobal returns whether the service is non-regionalized, using a single endpoint for all Regions in the partition.
+func (s Service) IsGlobal() bool {
+	return s.partitionEndpoint != ""
+}
+
+// Endpoints returns a map of the service's endpoints, indexed by their ID.
+func (s Service) Endpoints() map[string]Endpoint {
+	return maps.Clone(s.endpoints)
+}
+
+// EndpointForRegion returns the service's endpoint used in the specified Region.
+// A missing endpoint does not mean that the service is unavailable in the Region,
+// since the Region may be newer than the endpoint data; use Partition.IsServiceAvailableIn to check availability.
+func (s Service) EndpointForRegion(regionID string) (Endpoint, bool) {
+	if s.partitionEndpoint != "" {
+		regionID = s.partitionEndpoint
+	}
+
+	e, ok := s.endpoints[regionID]
+
+	return e, ok
+}
+
+// SupportsFIPS returns whether the service has a FIPS endpoint in the specified Region.
+func (s Service) SupportsFIPS(regionID string) bool {
+	e, ok := s.EndpointForRegion(regionID)
+
+	return ok && e.HasVariant(FIPSVariant)
+}
+
+// SupportsDualStack returns whether the service has a dual-stack endpoint in the specified Region.
+func (s Service) SupportsDualStack(regionID string) bool {
+	e, ok := s.EndpointForRegion(regionID)
+
+	return ok && e.HasVariant(DualStackVariant)
+}
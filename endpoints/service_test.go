@@ -0,0 +1,289 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package endpoints_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/aws-sdk-go-base/v2/endpoints"
+)
+
+func TestServiceVariants(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]struct {
+		partitionID       string
+		serviceID         string
+		regionID          string
+		expectedFIPS      bool
+		expectedDualStack bool
+	}{
+		"dynamodb us-east-1": {
+			partitionID:  endpoints.AwsPartitionID,
+			serviceID:    "dynamodb",
+			regionID:     endpoints.UsEast1RegionID,
+			expectedFIPS: true,
+		},
+		"dynamodb eu-west-1": {
+			partitionID: endpoints.AwsPartitionID,
+			serviceID:   "dynamodb",
+			regionID:    endpoints.EuWest1RegionID,
+		},
+		"s3 us-west-2": {
+			partitionID:       endpoints.AwsPartitionID,
+			serviceID:         "s3",
+			regionID:          endpoints.UsWest2RegionID,
+			expectedFIPS:      true,
+			expectedDualStack: true,
+		},
+		"s3 eu-west-1": {
+			partitionID:       endpoints.AwsPartitionID,
+			serviceID:         "s3",
+			regionID:          endpoints.EuWest1RegionID,
+			expectedDualStack: true,
+		},
+		"iam non-regionalized": {
+			partitionID:  endpoints.AwsPartitionID,
+			serviceID:    "iam",
+			regionID:     endpoints.EuWest1RegionID,
+			expectedFIPS: true,
+		},
+		"unknown region": {
+			partitionID: endpoints.AwsPartitionID,
+			serviceID:   "dynamodb",
+			regionID:    "not-a-region",
+		},
+	}
+
+	ps := endpoints.DefaultPartitions()
+	for name, testcase := range testcases {
+		var partition endpoints.Partition
+		for _, p := range ps {
+			if p.ID() == testcase.partitionID {
+				partition = p
+				break
+			}
+		}
+
+		service, ok := partition.Service(testcase.serviceID)
+		if !ok {
+			t.Fatalf("%s: service %q not found", name, testcase.serviceID)
+		}
+
+		if got := service.SupportsFIPS(testcase.regionID); got != testcase.expectedFIPS {
+			t.Errorf("%s: expected SupportsFIPS %t, got %t", name, testcase.expectedFIPS, got)
+		}
+		if got := service.SupportsDualStack(testcase.regionID); got != testcase.expectedDualStack {
+			t.Errorf("%s: expected SupportsDualStack %t, got %t", name, testcase.expectedDualStack, got)
+		}
+	}
+}
+
+func TestServiceEndpoint(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]struct {
+		serviceID                   string
+		regionID                    string
+		expectedFound               bool
+		expectedID                  string
+		expectedHostname            string
+		expectedFIPSHostname        string
+		expectedDeprecated          bool
+		expectedSigningRegion       string
+		expectedVariants            []endpoints.EndpointVariant
+		expectedCredentialScope     string
+		expectedServiceIsGlobal     bool
+		expectedServiceAvailable    bool
+		expectedAvailabilityUnknown bool
+	}{
+		"regional": {
+			serviceID:                "dynamodb",
+			regionID:                 endpoints.UsEast1RegionID,
+			expectedFound:            true,
+			expectedID:               endpoints.UsEast1RegionID,
+			expectedHostname:         "dynamodb.us-east-1.amazonaws.com",
+			expectedFIPSHostname:     "dynamodb-fips.us-east-1.amazonaws.com",
+			expectedSigningRegion:    endpoints.UsEast1RegionID,
+			expectedVariants:         []endpoints.EndpointVariant{endpoints.FIPSVariant},
+			expectedServiceAvailable: true,
+		},
+		"dual-stack": {
+			serviceID:             "s3",
+			regionID:              endpoints.UsWest2RegionID,
+			expectedFound:         true,
+			expectedID:            endpoints.UsWest2RegionID,
+			expectedHostname:      "s3.us-west-2.amazonaws.com",
+			expectedFIPSHostname:  "s3-fips.us-west-2.amazonaws.com",
+			expectedSigningRegion: endpoints.UsWest2RegionID,
+			expectedVariants: []endpoints.EndpointVariant{
+				endpoints.FIPSVariant,
+				endpoints.DualStackVariant,
+				endpoints.DualStackVariant | endpoints.FIPSVariant,
+			},
+			expectedServiceAvailable: true,
+		},
+		"global": {
+			serviceID:                "iam",
+			regionID:                 endpoints.EuWest1RegionID,
+			expectedFound:            true,
+			expectedID:               "aws-global",
+			expectedHostname:         "iam.amazonaws.com",
+			expectedFIPSHostname:     "iam-fips.amazonaws.com",
+			expectedSigningRegion:    endpoints.UsEast1RegionID,
+			expectedVariants:         []endpoints.EndpointVariant{endpoints.FIPSVariant},
+			expectedCredentialScope:  endpoints.UsEast1RegionID,
+			expectedServiceIsGlobal:  true,
+			expectedServiceAvailable: true,
+		},
+		"deprecated": {
+			serviceID:                "dynamodb",
+			regionID:                 "us-east-1-fips",
+			expectedFound:            true,
+			expectedID:               "us-east-1-fips",
+			expectedHostname:         "dynamodb-fips.us-east-1.amazonaws.com",
+			expectedDeprecated:       true,
+			expectedSigningRegion:    endpoints.UsEast1RegionID,
+			expectedVariants:         []endpoints.EndpointVariant{},
+			expectedCredentialScope:  endpoints.UsEast1RegionID,
+			expectedServiceAvailable: true,
+		},
+		"not available": {
+			serviceID:                   "dynamodb",
+			regionID:                    "not-a-region",
+			expectedAvailabilityUnknown: true,
+		},
+	}
+
+	partition, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), endpoints.UsEast1RegionID)
+	if !ok {
+		t.Fatal("partition not found")
+	}
+
+	for name, testcase := range testcases {
+		service, ok := partition.Service(testcase.serviceID)
+		if !ok {
+			t.Fatalf("%s: service %q not found", name, testcase.serviceID)
+		}
+
+		if got := service.IsGlobal(); got != testcase.expectedServiceIsGlobal {
+			t.Errorf("%s: expected IsGlobal %t, got %t", name, testcase.expectedServiceIsGlobal, got)
+		}
+		available, known := partition.IsServiceAvailableIn(testcase.serviceID, testcase.regionID)
+		if available != testcase.expectedServiceAvailable {
+			t.Errorf("%s: expected IsServiceAvailableIn %t, got %t", name, testcase.expectedServiceAvailable, available)
+		}
+		if known == testcase.expectedAvailabilityUnknown {
+			t.Errorf("%s: expected IsServiceAvailableIn known %t, got %t", name, !testcase.expectedAvailabilityUnknown, known)
+		}
+
+		endpoint, ok := partition.ServiceEndpoint(testcase.serviceID, testcase.regionID)
+		if ok != testcase.expectedFound {
+			t.Fatalf("%s: expected found %t, got %t", name, testcase.expectedFound, ok)
+		}
+		if !ok {
+			continue
+		}
+
+		if got := endpoint.ID(); got != testcase.expectedID {
+			t.Errorf("%s: expected ID %q, got %q", name, testcase.expectedID, got)
+		}
+		if got := endpoint.Hostname(); got != testcase.expectedHostname {
+			t.Errorf("%s: expected Hostname %q, got %q", name, testcase.expectedHostname, got)
+		}
+		if got, _ := endpoint.VariantHostname(endpoints.FIPSVariant); got != testcase.expectedFIPSHostname {
+			t.Errorf("%s: expected FIPS hostname %q, got %q", name, testcase.expectedFIPSHostname, got)
+		}
+		if got := endpoint.Deprecated(); got != testcase.expectedDeprecated {
+			t.Errorf("%s: expected Deprecated %t, got %t", name, testcase.expectedDeprecated, got)
+		}
+		if got := endpoint.SigningRegion(); got != testcase.expectedSigningRegion {
+			t.Errorf("%s: expected SigningRegion %q, got %q", name, testcase.expectedSigningRegion, got)
+		}
+		if got := endpoint.CredentialScope().Region(); got != testcase.expectedCredentialScope {
+			t.Errorf("%s: expected credential scope Region %q, got %q", name, testcase.expectedCredentialScope, got)
+		}
+		if got := endpoint.Variants(); !slices.Equal(got, testcase.expectedVariants) {
+			t.Errorf("%s: expected Variants %v, got %v", name, testcase.expectedVariants, got)
+		}
+	}
+}
+
+func TestPartitionServicesInRegion(t *testing.T) {
+	t.Parallel()
+
+	partition, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), endpoints.UsEast1RegionID)
+	if !ok {
+		t.Fatal("partition not found")
+	}
+
+	services, ok := partition.ServicesInRegion(endpoints.UsEast1RegionID)
+	if !ok {
+		t.Fatal("expected service endpoint data for Region")
+	}
+	for _, id := range []string{"dynamodb", "iam", "s3", "sts"} {
+		if _, ok := services[id]; !ok {
+			t.Errorf("expected service %q in Region", id)
+		}
+	}
+	if len(services) >= len(partition.Services()) {
+		t.Errorf("expected fewer services in Region (%d) than in partition (%d)", len(services), len(partition.Services()))
+	}
+}
+
+// Service endpoint data isn't available for Regions launched after it was last updated.
+func TestPartitionServicesInRecentRegion(t *testing.T) {
+	t.Parallel()
+
+	partition, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), endpoints.MxCentral1RegionID)
+	if !ok {
+		t.Fatal("partition not found")
+	}
+
+	if _, ok := partition.Regions()[endpoints.MxCentral1RegionID]; !ok {
+		t.Fatalf("expected Region %q in partition", endpoints.MxCentral1RegionID)
+	}
+
+	services, ok := partition.ServicesInRegion(endpoints.MxCentral1RegionID)
+	if ok {
+		t.Fatal("expected no service endpoint data for Region")
+	}
+	for id, service := range services {
+		if !service.IsGlobal() {
+			t.Errorf("expected only global services, got %q", id)
+		}
+	}
+	if _, ok := services["iam"]; !ok {
+		t.Error(`expected global service "iam" in Region`)
+	}
+
+	testcases := map[string]struct {
+		serviceID         string
+		expectedAvailable bool
+		expectedKnown     bool
+	}{
+		"global": {
+			serviceID:         "iam",
+			expectedAvailable: true,
+			expectedKnown:     true,
+		},
+		"regional": {
+			serviceID: "sts",
+		},
+		"unknown service": {
+			serviceID: "not-a-service",
+		},
+	}
+
+	for name, testcase := range testcases {
+		available, known := partition.IsServiceAvailableIn(testcase.serviceID, endpoints.MxCentral1RegionID)
+		if available != testcase.expectedAvailable {
+			t.Errorf("%s: expected available %t, got %t", name, testcase.expectedAvailable, available)
+		}
+		if known != testcase.expectedKnown {
+			t.Errorf("%s: expected known %t, got %t", name, testcase.expectedKnown, known)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package awsbase
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/hashicorp/aws-sdk-go-base/v2/endpoints"
+	"github.com/hashicorp/aws-sdk-go-base/v2/logging"
+)
+
+const fipsHostnameSuffix = "-fips"
+
+// fipsFallbackServices returns the IDs of the services which have an endpoint in the Region but no FIPS endpoint.
+func fipsFallbackServices(regionID string) []string {
+	partition, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), regionID)
+	if !ok {
+		return nil
+	}
+
+	var serviceIDs []string
+	for id, service := range partition.Services() {
+		if _, ok := service.EndpointForRegion(regionID); ok && !service.SupportsFIPS(regionID) {
+			serviceIDs = append(serviceIDs, id)
+		}
+	}
+	sort.Strings(serviceIDs)
+
+	return serviceIDs
+}
+
+// fipsFallback returns an API option which replaces the FIPS endpoint resolved for a service which has no FIPS endpoint
+// in the Region with the service's standard endpoint.
+// FIPS endpoint use is a client option and cannot be set per service on aws.Config, so the replacement is done after endpoint resolution
+// and before the request is signed.
+func fipsFallback() func(*middleware.Stack) error {
+	return func(stack *middleware.Stack) error {
+		if _, ok := stack.Finalize.Get("ResolveEndpointV2"); !ok {
+			return nil
+		}
+		return stack.Finalize.Insert(fipsFallbackMiddleware(), "ResolveEndpointV2", middleware.After)
+	}
+}
+
+func fipsFallbackMiddleware() middleware.FinalizeMiddleware {
+	return middleware.FinalizeMiddlewareFunc("FIPSFallback", func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (
+		out middleware.FinalizeOutput, metadata middleware.Metadata, err error,
+	) {
+		req, ok := in.Request.(*smithyhttp.Request)
+		if !ok {
+			return next.HandleFinalize(ctx, in)
+		}
+
+		regionID := awsmiddleware.GetRegion(ctx)
+		partition, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), regionID)
+		if !ok {
+			return next.HandleFinalize(ctx, in)
+		}
+
+		// FIPS hostnames have the form "{service}-fips.{region}.{dnsSuffix}", optionally prefixed by an S3 bucket name.
+		labels := strings.Split(req.URL.Host, ".")
+		for i, label := range labels {
+			serviceID, ok := strings.CutSuffix(label, fipsHostnameSuffix)
+			if !ok {
+				continue
+			}
+
+			service, ok := partition.Service(serviceID)
+			if !ok {
+				break
+			}
+
+			if _, ok := service.EndpointForRegion(regionID); ok && !service.SupportsFIPS(regionID) {
+				labels[i] = serviceID
+				host := strings.Join(labels, ".")
+
+				logging.RetrieveLogger(ctx).Debug(ctx, "FIPS endpoint not available, using standard endpoint", map[string]any{
+					"tf_aws.fips_fallback.service":  serviceID,
+					"tf_aws.fips_fallback.endpoint": host,
+				})
+
+				req.URL.Host = host
+			}
+
+			break
+		}
+
+		return next.HandleFinalize(ctx, in)
+	})
+}
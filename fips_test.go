@@ -0,0 +1,148 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package awsbase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/hashicorp/aws-sdk-go-base/v2/diag"
+	"github.com/hashicorp/aws-sdk-go-base/v2/servicemocks"
+)
+
+func TestFIPSEndpointMode(t *testing.T) {
+	testcases := map[string]struct {
+		Region           string
+		FIPSEndpointMode FIPSEndpointMode
+		Operation        func(context.Context, aws.Config, func(*middleware.Stack) error) error
+		ExpectedHost     string
+		ExpectedWarning  bool
+	}{
+		"all FIPS available": {
+			Region:       "us-east-1",
+			Operation:    dynamodbListTables,
+			ExpectedHost: "dynamodb-fips.us-east-1.amazonaws.com",
+		},
+		"all FIPS not available": {
+			Region:       "eu-west-1",
+			Operation:    dynamodbListTables,
+			ExpectedHost: "dynamodb-fips.eu-west-1.amazonaws.com",
+		},
+		"where available FIPS available": {
+			Region:           "us-east-1",
+			FIPSEndpointMode: FIPSEndpointModeWhereAvailable,
+			Operation:        dynamodbListTables,
+			ExpectedHost:     "dynamodb-fips.us-east-1.amazonaws.com",
+			ExpectedWarning:  true,
+		},
+		"where available FIPS not available": {
+			Region:           "eu-west-1",
+			FIPSEndpointMode: FIPSEndpointModeWhereAvailable,
+			Operation:        dynamodbListTables,
+			ExpectedHost:     "dynamodb.eu-west-1.amazonaws.com",
+			ExpectedWarning:  true,
+		},
+		"where available FIPS not available S3 virtual-hosted-style": {
+			Region:           "eu-west-1",
+			FIPSEndpointMode: FIPSEndpointModeWhereAvailable,
+			Operation:        s3HeadBucket,
+			ExpectedHost:     "test-bucket.s3.eu-west-1.amazonaws.com",
+			ExpectedWarning:  true,
+		},
+	}
+
+	for name, testcase := range testcases {
+		testcase := testcase
+
+		t.Run(name, func(t *testing.T) {
+			servicemocks.InitSessionTestEnv(t)
+
+			c := &Config{
+				AccessKey:           servicemocks.MockStaticAccessKey,
+				SecretKey:           servicemocks.MockStaticSecretKey,
+				FIPSEndpointMode:    testcase.FIPSEndpointMode,
+				Region:              testcase.Region,
+				SkipCredsValidation: true,
+				UseFIPSEndpoint:     true,
+			}
+
+			ctx, awsConfig, diags := GetAwsConfig(context.Background(), c)
+			if diags.HasError() {
+				t.Fatalf("error in GetAwsConfig(): %v", diags)
+			}
+
+			if a, e := slices.ContainsFunc(diags, func(d diag.Diagnostic) bool {
+				return d.Summary() == "FIPS Endpoints Not Available"
+			}), testcase.ExpectedWarning; a != e {
+				t.Errorf("expected FIPS warning %t, got %t: %v", e, a, diags)
+			}
+
+			var host string
+			err := testcase.Operation(ctx, awsConfig, captureRequestHost(&host))
+			if !errors.Is(err, errRequestCaptured) {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if host != testcase.ExpectedHost {
+				t.Errorf("expected host %q, got %q", testcase.ExpectedHost, host)
+			}
+		})
+	}
+}
+
+func TestFIPSFallbackServices(t *testing.T) {
+	serviceIDs := fipsFallbackServices("eu-west-1")
+
+	if !slices.Contains(serviceIDs, "dynamodb") {
+		t.Errorf("expected %q in FIPS fallback services: %v", "dynamodb", serviceIDs)
+	}
+	if slices.Contains(serviceIDs, "iam") {
+		t.Errorf("expected %q not in FIPS fallback services: %v", "iam", serviceIDs)
+	}
+
+	if serviceIDs := fipsFallbackServices("not-a-region"); len(serviceIDs) != 0 {
+		t.Errorf("expected no FIPS fallback services for unknown Region, got %v", serviceIDs)
+	}
+}
+
+var errRequestCaptured = errors.New("request captured")
+
+// captureRequestHost returns an API option which records the host of the request to be sent and stops the request.
+func captureRequestHost(host *string) func(*middleware.Stack) error {
+	return func(stack *middleware.Stack) error {
+		return stack.Finalize.Add(middleware.FinalizeMiddlewareFunc("CaptureRequestHost", func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (
+			out middleware.FinalizeOutput, metadata middleware.Metadata, err error,
+		) {
+			if req, ok := in.Request.(*smithyhttp.Request); ok {
+				*host = req.URL.Host
+			}
+			return out, metadata, errRequestCaptured
+		}), middleware.After)
+	}
+}
+
+func dynamodbListTables(ctx context.Context, awsConfig aws.Config, apiOption func(*middleware.Stack) error) error {
+	_, err := dynamodb.NewFromConfig(awsConfig).ListTables(ctx, &dynamodb.ListTablesInput{}, func(o *dynamodb.Options) {
+		o.APIOptions = append(o.APIOptions, apiOption)
+		o.RetryMaxAttempts = 1
+	})
+	return err
+}
+
+func s3HeadBucket(ctx context.Context, awsConfig aws.Config, apiOption func(*middleware.Stack) error) error {
+	_, err := s3.NewFromConfig(awsConfig).HeadBucket(ctx, &s3.HeadBucketInput{
+		Bucket: aws.String("test-bucket"),
+	}, func(o *s3.Options) {
+		o.APIOptions = append(o.APIOptions, apiOption)
+		o.RetryMaxAttempts = 1
+	})
+	return err
+}
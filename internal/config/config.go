@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"slices"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -17,8 +18,12 @@ import (
 	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	"github.com/aws/aws-sdk-go-v2/feature/ec2/imds"
 	"github.com/hashicorp/aws-sdk-go-base/v2/diag"
+	"github.com/hashicorp/aws-sdk-go-base/v2/endpoints"
 	"github.com/hashicorp/aws-sdk-go-base/v2/internal/expand"
 	"github.com/hashicorp/aws-sdk-go-base/v2/logging"
+	"github.com/hashicorp/aws-sdk-go-base/v2/validation"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/net/http/httpproxy"
 )
 
@@ -29,6 +34,16 @@ const (
 	HTTPProxyModeSeparate
 )
 
+type FIPSEndpointMode int
+
+const (
+	// FIPSEndpointModeAll uses FIPS endpoints for all services when UseFIPSEndpoint is set.
+	FIPSEndpointModeAll FIPSEndpointMode = iota
+	// FIPSEndpointModeWhereAvailable uses FIPS endpoints when UseFIPSEndpoint is set only for services
+	// which have a FIPS endpoint in the Region, and standard endpoints for all other services.
+	FIPSEndpointModeWhereAvailable
+)
+
 type Config struct {
 	AccessKey                      string
 	AllowedAccountIds              []string
@@ -42,6 +57,9 @@ type Config struct {
 	EC2MetadataServiceEnableState  imds.ClientEnableState
 	EC2MetadataServiceEndpoint     string
 	EC2MetadataServiceEndpointMode string
+	EmulatorEndpoint               string
+	Endpoints                      map[string]string
+	FIPSEndpointMode               FIPSEndpointMode
 	ForbiddenAccountIds            []string
 	HTTPClient                     *http.Client
 	HTTPProxy                      *string
@@ -50,7 +68,10 @@ type Config struct {
 	Insecure                       bool
 	Logger                         logging.Logger
 	MaxBackoff                     time.Duration
+	MaxRequestBodyLogLength        int
+	MaxResponseBodyLogLength       int
 	MaxRetries                     int
+	MeterProvider                  metric.MeterProvider
 	NoProxy                        string
 	Profile                        string
 	HTTPProxyMode                  ProxyMode
@@ -67,6 +88,7 @@ type Config struct {
 	SuppressDebugLog               bool
 	Token                          string
 	TokenBucketRateLimiterCapacity int
+	TracerProvider                 trace.TracerProvider
 	UseDualStackEndpoint           bool
 	UseFIPSEndpoint                bool
 	UserAgent                      UserAgentProducts
@@ -186,6 +208,132 @@ func (c Config) ValidateProxySettings(diags *diag.Diagnostics) {
 	}
 }
 
+// EmulatorMode returns whether all services are directed to a local emulator such as LocalStack or moto.
+func (c Config) EmulatorMode() bool {
+	return c.EmulatorEndpoint != ""
+}
+
+// ValidateRegion verifies that the Region, if set, is a known AWS Region.
+// A Region which is not known but matches a partition's Region regex, such as a newly launched Region, is accepted.
+// Region validation is skipped in emulator mode, since emulators accept arbitrary Region names.
+func (c Config) ValidateRegion(diags *diag.Diagnostics) {
+	if c.Region == "" || c.EmulatorMode() {
+		return
+	}
+
+	if _, kind := endpoints.MatchPartitionForRegion(endpoints.DefaultPartitions(), c.Region); kind != endpoints.PartitionMatchKindNone {
+		return
+	}
+
+	if err := validation.SupportedRegion(c.Region); err != nil {
+		*diags = diags.AddError(
+			"Invalid AWS Region",
+			err.Error(),
+		)
+	}
+}
+
+// ValidateAssumeRoleARNs verifies that the role and policy ARNs of each assume role configuration are valid.
+// Unset role ARNs are reported when the role is assumed.
+func (c Config) ValidateAssumeRoleARNs(diags *diag.Diagnostics) {
+	validateRoleARNs := func(path, roleARN string, policyARNs []string) {
+		if roleARN != "" {
+			if err := validation.IAMRoleARN(roleARN); err != nil {
+				*diags = diags.AddError(
+					"Invalid Role ARN",
+					fmt.Sprintf("%s.RoleARN: %s", path, err),
+				)
+			}
+		}
+
+		for i, policyARN := range policyARNs {
+			if err := validation.IAMPolicyARN(policyARN); err != nil {
+				*diags = diags.AddError(
+					"Invalid Policy ARN",
+					fmt.Sprintf("%s.PolicyARNs[%d]: %s", path, i, err),
+				)
+			}
+		}
+	}
+
+	for i, ar := range c.AssumeRole {
+		validateRoleARNs(fmt.Sprintf("AssumeRole[%d]", i), ar.RoleARN, ar.PolicyARNs)
+	}
+	if ar := c.AssumeRoleWithWebIdentity; ar != nil {
+		validateRoleARNs("AssumeRoleWithWebIdentity", ar.RoleARN, ar.PolicyARNs)
+	}
+}
+
+// ValidateAssumeRolePolicies verifies that the session policy of each assume role configuration is a valid IAM policy document.
+func (c Config) ValidateAssumeRolePolicies(diags *diag.Diagnostics) {
+	validatePolicy := func(path, policy string) {
+		if policy == "" {
+			return
+		}
+
+		err := validation.SessionPolicy(policy)
+		if err == nil {
+			return
+		}
+
+		errs := []error{err}
+		if joined, ok := err.(interface{ Unwrap() []error }); ok { //nolint:errorlint
+			errs = joined.Unwrap()
+		}
+		for _, err := range errs {
+			*diags = diags.AddError(
+				"Invalid Session Policy",
+				fmt.Sprintf("%s.Policy: %s", path, err),
+			)
+		}
+	}
+
+	for i, ar := range c.AssumeRole {
+		validatePolicy(fmt.Sprintf("AssumeRole[%d]", i), ar.Policy)
+	}
+	if ar := c.AssumeRoleWithWebIdentity; ar != nil {
+		validatePolicy("AssumeRoleWithWebIdentity", ar.Policy)
+	}
+}
+
+// ValidateEndpoints verifies that the emulator endpoint and each custom service endpoint is an absolute URL.
+func (c Config) ValidateEndpoints(diags *diag.Diagnostics) {
+	if endpoint := c.EmulatorEndpoint; endpoint != "" {
+		if u, err := url.Parse(endpoint); err != nil {
+			*diags = diags.AddError(
+				"Invalid Emulator Endpoint",
+				fmt.Sprintf("Unable to parse URL: %s", err),
+			)
+		} else if !u.IsAbs() || u.Host == "" {
+			*diags = diags.AddError(
+				"Invalid Emulator Endpoint",
+				fmt.Sprintf("Emulator endpoint must be an absolute URL, got %q", endpoint),
+			)
+		}
+	}
+
+	serviceIDs := make([]string, 0, len(c.Endpoints))
+	for serviceID := range c.Endpoints {
+		serviceIDs = append(serviceIDs, serviceID)
+	}
+	slices.Sort(serviceIDs)
+
+	for _, serviceID := range serviceIDs {
+		endpoint := c.Endpoints[serviceID]
+		if u, err := url.Parse(endpoint); err != nil {
+			*diags = diags.AddError(
+				"Invalid Service Endpoint",
+				fmt.Sprintf("Unable to parse URL for service %q: %s", serviceID, err),
+			)
+		} else if !u.IsAbs() || u.Host == "" {
+			*diags = diags.AddError(
+				"Invalid Service Endpoint",
+				fmt.Sprintf("Endpoint for service %q must be an absolute URL, got %q", serviceID, endpoint),
+			)
+		}
+	}
+}
+
 const (
 	missingHttpsProxyWarningSummary   = "Missing HTTPS Proxy"
 	missingHttpsProxyDetailProblem    = "An HTTP proxy was set but no HTTPS proxy was."
@@ -222,3 +222,275 @@ func TestValidateProxyConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateEndpoints(t *testing.T) {
+	testcases := map[string]struct {
+		config        Config
+		expectedDiags diag.Diagnostics
+	}{
+		"no endpoints": {
+			config: Config{},
+		},
+		"valid endpoints": {
+			config: Config{
+				Endpoints: map[string]string{
+					"S3":       "http://localhost:4566",
+					"DynamoDB": "https://dynamodb.example.com/prefix",
+				},
+			},
+		},
+		"relative endpoint": {
+			config: Config{
+				Endpoints: map[string]string{
+					"S3": "localhost:4566",
+				},
+			},
+			expectedDiags: diag.Diagnostics{
+				diag.NewErrorDiagnostic(
+					"Invalid Service Endpoint",
+					`Endpoint for service "S3" must be an absolute URL, got "localhost:4566"`,
+				),
+			},
+		},
+		"invalid endpoint": {
+			config: Config{
+				Endpoints: map[string]string{
+					"SQS": "http://local host",
+				},
+			},
+			expectedDiags: diag.Diagnostics{
+				diag.NewErrorDiagnostic(
+					"Invalid Service Endpoint",
+					fmt.Sprintf("Unable to parse URL for service %q: %s", "SQS", &url.Error{
+						Op:  "parse",
+						URL: "http://local host",
+						Err: url.InvalidHostError(" "),
+					}),
+				),
+			},
+		},
+		"valid emulator endpoint": {
+			config: Config{
+				EmulatorEndpoint: "http://localhost:4566",
+			},
+		},
+		"relative emulator endpoint": {
+			config: Config{
+				EmulatorEndpoint: "localhost:4566",
+			},
+			expectedDiags: diag.Diagnostics{
+				diag.NewErrorDiagnostic(
+					"Invalid Emulator Endpoint",
+					`Emulator endpoint must be an absolute URL, got "localhost:4566"`,
+				),
+			},
+		},
+	}
+
+	for name, testcase := range testcases {
+		testcase := testcase
+
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+
+			testcase.config.ValidateEndpoints(&diags)
+
+			if diff := cmp.Diff(diags, testcase.expectedDiags); diff != "" {
+				t.Errorf("unexpected diagnostics difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateRegion(t *testing.T) {
+	testcases := map[string]struct {
+		config      Config
+		expectError bool
+	}{
+		"no region": {},
+		"valid region": {
+			config: Config{
+				Region: "us-east-1",
+			},
+		},
+		"unknown region matching partition": {
+			config: Config{
+				Region: "us-west-17",
+			},
+		},
+		"invalid region": {
+			config: Config{
+				Region: "local",
+			},
+			expectError: true,
+		},
+		"invalid region in emulator mode": {
+			config: Config{
+				EmulatorEndpoint: "http://localhost:4566",
+				Region:           "local",
+			},
+		},
+	}
+
+	for name, testcase := range testcases {
+		testcase := testcase
+
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+
+			testcase.config.ValidateRegion(&diags)
+
+			if a, e := diags.HasError(), testcase.expectError; a != e {
+				t.Errorf("expected error %t, got %t: %v", e, a, diags)
+			}
+		})
+	}
+}
+
+func TestValidateAssumeRoleARNs(t *testing.T) {
+	testcases := map[string]struct {
+		config        Config
+		expectedDiags diag.Diagnostics
+	}{
+		"no assume role": {
+			config: Config{},
+		},
+		"valid ARNs": {
+			config: Config{
+				AssumeRole: []AssumeRole{{
+					RoleARN: "arn:aws:iam::123456789012:role/path/Role",
+					PolicyARNs: []string{
+						"arn:aws:iam::123456789012:policy/Policy",
+						"arn:aws:iam::aws:policy/ReadOnlyAccess",
+					},
+				}},
+				AssumeRoleWithWebIdentity: &AssumeRoleWithWebIdentity{
+					RoleARN: "arn:aws-us-gov:iam::123456789012:role/Role",
+				},
+			},
+		},
+		"unset role ARN": {
+			config: Config{
+				AssumeRole: []AssumeRole{{}},
+			},
+		},
+		"invalid ARNs": {
+			config: Config{
+				AssumeRole: []AssumeRole{
+					{
+						RoleARN: "arn:aws:iam::123456789012:role/Role",
+					},
+					{
+						RoleARN: "arn:aws:iam::123456789012:user/User",
+						PolicyARNs: []string{
+							"arn:aws:iam::123456789012:policy/Policy",
+							"arn:aws:iam::aws:role/Role",
+						},
+					},
+				},
+				AssumeRoleWithWebIdentity: &AssumeRoleWithWebIdentity{
+					RoleARN: "arn:aws-example:iam::123456789012:role/Role",
+				},
+			},
+			expectedDiags: diag.Diagnostics{
+				diag.NewErrorDiagnostic(
+					"Invalid Role ARN",
+					`AssumeRole[1].RoleARN: invalid ARN (arn:aws:iam::123456789012:user/User): expected resource type "role", got "user"`,
+				),
+				diag.NewErrorDiagnostic(
+					"Invalid Policy ARN",
+					`AssumeRole[1].PolicyARNs[1]: invalid ARN (arn:aws:iam::aws:role/Role): expected resource type "policy", got "role"`,
+				),
+				diag.NewErrorDiagnostic(
+					"Invalid Role ARN",
+					`AssumeRoleWithWebIdentity.RoleARN: invalid ARN (arn:aws-example:iam::123456789012:role/Role): unknown partition "aws-example"`,
+				),
+			},
+		},
+	}
+
+	for name, testcase := range testcases {
+		testcase := testcase
+
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+
+			testcase.config.ValidateAssumeRoleARNs(&diags)
+
+			if diff := cmp.Diff(diags, testcase.expectedDiags); diff != "" {
+				t.Errorf("unexpected diagnostics difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateAssumeRolePolicies(t *testing.T) {
+	testcases := map[string]struct {
+		config        Config
+		expectedDiags diag.Diagnostics
+	}{
+		"no policies": {
+			config: Config{
+				AssumeRole: []AssumeRole{{}},
+			},
+		},
+		"valid policy": {
+			config: Config{
+				AssumeRole: []AssumeRole{{
+					Policy: `{"Version": "2012-10-17", "Statement": {"Effect": "Allow", "Action": "s3:*", "Resource": "*"}}`,
+				}},
+			},
+		},
+		"empty policy": {
+			config: Config{
+				AssumeRole: []AssumeRole{{
+					Policy: `{}`,
+				}},
+				AssumeRoleWithWebIdentity: &AssumeRoleWithWebIdentity{
+					Policy: `{}`,
+				},
+			},
+		},
+		"invalid policies": {
+			config: Config{
+				AssumeRole: []AssumeRole{
+					{},
+					{
+						Policy: `{"Statement": {"Effect": "Permit", "Resource": "*"}}`,
+					},
+				},
+				AssumeRoleWithWebIdentity: &AssumeRoleWithWebIdentity{
+					Policy: `{"Statement": }`,
+				},
+			},
+			expectedDiags: diag.Diagnostics{
+				diag.NewErrorDiagnostic(
+					"Invalid Session Policy",
+					`AssumeRole[1].Policy: line 1, column 26: Statement.Effect: must be "Allow" or "Deny"`,
+				),
+				diag.NewErrorDiagnostic(
+					"Invalid Session Policy",
+					`AssumeRole[1].Policy: line 1, column 15: Statement: one of Action or NotAction is required`,
+				),
+				diag.NewErrorDiagnostic(
+					"Invalid Session Policy",
+					`AssumeRoleWithWebIdentity.Policy: line 1, column 15: missing value after object key`,
+				),
+			},
+		},
+	}
+
+	for name, testcase := range testcases {
+		testcase := testcase
+
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+
+			testcase.config.ValidateAssumeRolePolicies(&diags)
+
+			if diff := cmp.Diff(diags, testcase.expectedDiags); diff != "" {
+				t.Errorf("unexpected diagnostics difference: %s", diff)
+			}
+		})
+	}
+}
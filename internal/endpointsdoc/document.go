@@ -0,0 +1,272 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package endpointsdoc reads partition definitions from AWS SDK endpoints and partitions documents.
+// It is used both to generate the built-in partitions and to load partitions at runtime.
+package endpointsdoc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Variant is a combination of endpoint variant tags.
+// Values are the same as those of endpoints.EndpointVariant.
+type Variant uint8
+
+const (
+	FIPSVariant Variant = 1 << iota
+	DualStackVariant
+)
+
+// Partition is a partition definition with all endpoint values resolved.
+type Partition struct {
+	ID          string
+	Name        string
+	DNSSuffix   string
+	RegionRegex string
+	Outputs     *Outputs // Only set by partitions documents.
+	Regions     map[string]Region
+	Services    map[string]Service
+}
+
+// Outputs are the partition metadata values in a partitions document.
+type Outputs struct {
+	DNSSuffix            string `json:"dnsSuffix"`
+	DualStackDNSSuffix   string `json:"dualStackDnsSuffix"`
+	ImplicitGlobalRegion string `json:"implicitGlobalRegion"`
+	SupportsDualStack    bool   `json:"supportsDualStack"`
+	SupportsFIPS         bool   `json:"supportsFIPS"`
+}
+
+type Region struct {
+	Description string `json:"description"`
+}
+
+type Service struct {
+	PartitionEndpoint string
+	Endpoints         map[string]Endpoint // Indexed by Region.
+}
+
+type Endpoint struct {
+	Hostname        string
+	Variants        map[Variant]string
+	Deprecated      bool
+	CredentialScope CredentialScope
+}
+
+type CredentialScope struct {
+	Region  string `json:"region"`
+	Service string `json:"service"`
+}
+
+// document is either an AWS SDK endpoints document (https://github.com/aws/aws-sdk-go-v2/blob/main/codegen/smithy-aws-go-codegen/src/main/resources/software/amazon/smithy/aws/go/codegen/endpoints.json)
+// or an AWS SDK partitions document (https://github.com/aws/aws-sdk-go-v2/blob/main/internal/endpoints/awsrulesfn/partitions.json).
+type document struct {
+	Partitions []partitionDocument `json:"partitions"`
+}
+
+type partitionDocument struct {
+	// endpoints.json.
+	Partition     string                     `json:"partition"`
+	PartitionName string                     `json:"partitionName"`
+	DNSSuffix     string                     `json:"dnsSuffix"`
+	Defaults      endpointDocument           `json:"defaults"`
+	Services      map[string]serviceDocument `json:"services"`
+
+	// partitions.json.
+	ID      string   `json:"id"`
+	Outputs *Outputs `json:"outputs"`
+
+	// Both.
+	RegionRegex string            `json:"regionRegex"`
+	Regions     map[string]Region `json:"regions"`
+}
+
+type serviceDocument struct {
+	Defaults          endpointDocument            `json:"defaults"`
+	Endpoints         map[string]endpointDocument `json:"endpoints"`
+	IsRegionalized    *bool                       `json:"isRegionalized"`
+	PartitionEndpoint string                      `json:"partitionEndpoint"`
+}
+
+type endpointDocument struct {
+	Hostname        string                    `json:"hostname"`
+	CredentialScope CredentialScope           `json:"credentialScope"`
+	Deprecated      bool                      `json:"deprecated"`
+	Variants        []endpointVariantDocument `json:"variants"`
+}
+
+type endpointVariantDocument struct {
+	Hostname  string   `json:"hostname"`
+	DNSSuffix string   `json:"dnsSuffix"`
+	Tags      []string `json:"tags"`
+}
+
+func (d endpointVariantDocument) variant() (Variant, bool) {
+	var v Variant
+	for _, tag := range d.Tags {
+		switch tag {
+		case "dualstack":
+			v |= DualStackVariant
+		case "fips":
+			v |= FIPSVariant
+		}
+	}
+	return v, v != 0
+}
+
+// Decode reads the partitions in an AWS SDK endpoints or partitions JSON document.
+func Decode(r io.Reader) ([]Partition, error) {
+	var doc document
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("decoding partitions document: %w", err)
+	}
+
+	ps := make([]Partition, 0, len(doc.Partitions))
+	for _, pd := range doc.Partitions {
+		p, err := pd.partition()
+		if err != nil {
+			return nil, err
+		}
+		ps = append(ps, p)
+	}
+
+	return ps, nil
+}
+
+func (d partitionDocument) partition() (Partition, error) {
+	p := Partition{
+		ID:          d.Partition,
+		Name:        d.PartitionName,
+		DNSSuffix:   d.DNSSuffix,
+		RegionRegex: d.RegionRegex,
+		Outputs:     d.Outputs,
+		Regions:     make(map[string]Region, len(d.Regions)),
+		Services:    make(map[string]Service, len(d.Services)),
+	}
+	if p.ID == "" {
+		p.ID = d.ID
+	}
+	if p.ID == "" {
+		return Partition{}, errors.New("partition ID not set")
+	}
+	if p.DNSSuffix == "" && p.Outputs != nil {
+		p.DNSSuffix = p.Outputs.DNSSuffix
+	}
+
+	for id, r := range d.Regions {
+		// Partition-wide pseudo Regions such as "aws-global" are not included.
+		if strings.HasSuffix(id, "-global") {
+			continue
+		}
+		p.Regions[id] = r
+	}
+
+	for id, sd := range d.Services {
+		s := Service{
+			Endpoints: make(map[string]Endpoint, len(sd.Endpoints)),
+		}
+		if sd.IsRegionalized != nil && !*sd.IsRegionalized {
+			s.PartitionEndpoint = sd.PartitionEndpoint
+		}
+
+		for region, ed := range sd.Endpoints {
+			r := endpointResolver{
+				service:   id,
+				region:    region,
+				dnsSuffix: p.DNSSuffix,
+				levels:    []endpointDocument{ed, sd.Defaults, d.Defaults},
+			}
+			s.Endpoints[region] = r.resolve()
+		}
+
+		p.Services[id] = s
+	}
+
+	return p, nil
+}
+
+// endpointResolver resolves an endpoint's values, following the endpoints document inheritance rules.
+// Values are inherited from the service defaults, then the partition defaults.
+type endpointResolver struct {
+	service   string
+	region    string
+	dnsSuffix string
+	levels    []endpointDocument // Endpoint, service defaults, partition defaults.
+}
+
+func (r endpointResolver) resolve() Endpoint {
+	e := Endpoint{
+		Deprecated: r.levels[0].Deprecated,
+	}
+
+	for _, level := range r.levels {
+		if e.Hostname == "" {
+			e.Hostname = r.expand(level.Hostname, r.dnsSuffix)
+		}
+	}
+
+	for _, level := range r.levels[:2] {
+		if e.CredentialScope.Region == "" {
+			e.CredentialScope.Region = level.CredentialScope.Region
+		}
+		if e.CredentialScope.Service == "" {
+			e.CredentialScope.Service = level.CredentialScope.Service
+		}
+	}
+
+	// Only variants defined for the endpoint or in the service defaults are available.
+	// Variants in the partition defaults only provide default values.
+	for _, level := range r.levels[:2] {
+		for _, vd := range level.Variants {
+			v, ok := vd.variant()
+			if !ok {
+				continue
+			}
+			if e.Variants == nil {
+				e.Variants = make(map[Variant]string)
+			}
+			if _, ok := e.Variants[v]; !ok {
+				e.Variants[v] = r.variantHostname(v)
+			}
+		}
+	}
+
+	return e
+}
+
+func (r endpointResolver) variantHostname(v Variant) string {
+	var hostname, dnsSuffix string
+
+	for _, level := range r.levels {
+		for _, vd := range level.Variants {
+			if tv, ok := vd.variant(); !ok || tv != v {
+				continue
+			}
+			if hostname == "" {
+				hostname = vd.Hostname
+			}
+			if dnsSuffix == "" {
+				dnsSuffix = vd.DNSSuffix
+			}
+		}
+	}
+	if dnsSuffix == "" {
+		dnsSuffix = r.dnsSuffix
+	}
+
+	return r.expand(hostname, dnsSuffix)
+}
+
+// expand expands a hostname template, e.g. "{service}-fips.{region}.{dnsSuffix}".
+func (r endpointResolver) expand(hostname, dnsSuffix string) string {
+	return strings.NewReplacer(
+		"{service}", r.service,
+		"{region}", r.region,
+		"{dnsSuffix}", dnsSuffix,
+	).Replace(hostname)
+}
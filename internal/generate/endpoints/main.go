@@ -7,46 +7,96 @@
 package main
 
 import (
+	"bytes"
+	"crypto/sha256"
 	_ "embed"
+	"encoding/hex"
 	"encoding/json"
 	"flag"
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
 	"html/template"
 	"io"
+	"maps"
 	"net/http"
 	"os"
+	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/hashicorp/aws-sdk-go-base/v2/internal/endpointsdoc"
 	"github.com/hashicorp/aws-sdk-go-base/v2/internal/generate/common"
 	"github.com/hashicorp/aws-sdk-go-base/v2/internal/slices"
 )
 
 type PartitionDatum struct {
-	ID          string
-	Name        string
-	DNSSuffix   string
-	RegionRegex string
-	Regions     []RegionDatum
-	Services    []ServiceDatum
+	ID                          string
+	Name                        string
+	DNSSuffix                   string
+	DualStackDNSSuffix          string
+	ImplicitGlobalRegion        string
+	ServicePrincipalDNSSuffix   string
+	ServicePrincipalDNSSuffixes map[string]string
+	SupportsDualStack           bool
+	SupportsFIPS                bool
+	RegionRegex                 string
+	Regions                     []RegionDatum
+	Services                    []ServiceDatum
 }
 
 type RegionDatum struct {
-	ID          string
-	Description string
+	ID              string
+	Description     string
+	Geography       string
+	OptInRequired   bool
+	LocalZoneParent bool
 }
 
 type ServiceDatum struct {
-	ID string
+	ID                string
+	PartitionEndpoint string
+	Endpoints         []EndpointDatum
+}
+
+type EndpointDatum struct {
+	ID                     string
+	Hostname               string
+	Variants               []VariantDatum
+	Deprecated             bool
+	CredentialScopeRegion  string
+	CredentialScopeService string
+}
+
+type VariantDatum struct {
+	Expr     string
+	Hostname string
+}
+
+type SourceDatum struct {
+	Name     string
+	Checksum string
 }
 
 type TemplateData struct {
+	Sources    []SourceDatum
 	Partitions []PartitionDatum
 }
 
+var (
+	endpointsChecksum  = flag.String("endpoints-sha256", "", "expected SHA-256 checksum of the endpoints document")
+	partitionsChecksum = flag.String("partitions-sha256", "", "expected SHA-256 checksum of the partitions document")
+	allowRemovals      = flag.Bool("allow-removals", false, "allow Regions and services to be removed from the generated file")
+)
+
 func usage() {
 	fmt.Fprintf(os.Stderr, "Usage:\n")
-	fmt.Fprintf(os.Stderr, "\tmain.go <aws-sdk-go-v2-endpoints-json-url>\n\n")
+	fmt.Fprintf(os.Stderr, "\tmain.go [flags] <aws-sdk-go-v2-endpoints-json> <aws-sdk-go-v2-partitions-json>\n\n")
+	fmt.Fprintf(os.Stderr, "Each source is an HTTP(S) URL, a local file path or \"-\" for standard input.\n\n")
+	fmt.Fprintf(os.Stderr, "Flags:\n")
+	flag.PrintDefaults()
 }
 
 func main() {
@@ -55,23 +105,67 @@ func main() {
 
 	args := flag.Args()
 
-	if len(args) < 1 {
+	if len(args) < 2 { //nolint:mnd
 		flag.Usage()
 		os.Exit(2)
 	}
 
-	inputURL := args[0]
+	inputSource := args[0]
+	partitionsSource := args[1]
 	filename := `endpoints_gen.go`
-	target := map[string]any{}
 
 	g := common.NewGenerator()
 	g.Infof("Generating endpoints/%s", filename)
 
-	if err := readHTTPJSON(inputURL, &target); err != nil {
-		g.Fatalf("error reading JSON from %s: %s", inputURL, err)
+	if inputSource == stdinSource && partitionsSource == stdinSource {
+		g.Fatalf("only one source can be read from standard input")
 	}
 
 	td := TemplateData{}
+
+	var endpointsDocument json.RawMessage
+	checksum, err := readJSON(inputSource, *endpointsChecksum, &endpointsDocument)
+	if err != nil {
+		g.Fatalf("error reading JSON from %s: %s", inputSource, err)
+	}
+	td.Sources = append(td.Sources, SourceDatum{Name: sourceName(inputSource), Checksum: checksum})
+
+	var partitionsDocument json.RawMessage
+	checksum, err = readJSON(partitionsSource, *partitionsChecksum, &partitionsDocument)
+	if err != nil {
+		g.Fatalf("error reading JSON from %s: %s", partitionsSource, err)
+	}
+	td.Sources = append(td.Sources, SourceDatum{Name: sourceName(partitionsSource), Checksum: checksum})
+
+	var version struct {
+		Version any `json:"version"`
+	}
+	if err := json.Unmarshal(endpointsDocument, &version); err != nil {
+		g.Fatalf("can't parse endpoints document version: %s", err)
+	}
+	if version, ok := version.Version.(float64); ok {
+		if version != 3.0 {
+			g.Fatalf("unsupported endpoints document version: %d", int(version))
+		}
+	} else {
+		g.Fatalf("can't parse endpoints document version")
+	}
+
+	endpointsPartitions, err := endpointsdoc.Decode(bytes.NewReader(endpointsDocument))
+	if err != nil {
+		g.Fatalf("error reading endpoints document: %s", err)
+	}
+
+	// Partition metadata are taken from the partitions document.
+	partitionsPartitions, err := endpointsdoc.Decode(bytes.NewReader(partitionsDocument))
+	if err != nil {
+		g.Fatalf("error reading partitions document: %s", err)
+	}
+	partitionsByID := make(map[string]endpointsdoc.Partition, len(partitionsPartitions))
+	for _, partition := range partitionsPartitions {
+		partitionsByID[partition.ID] = partition
+	}
+
 	templateFuncMap := template.FuncMap{
 		// IDToTitle splits a '-' or '.' separated string and returns a string with each part title cased.
 		"IDToTitle": func(s string) (string, error) {
@@ -85,85 +179,79 @@ func main() {
 		},
 	}
 
-	if version, ok := target["version"].(float64); ok {
-		if version != 3.0 {
-			g.Fatalf("unsupported endpoints document version: %d", int(version))
+	for _, partition := range endpointsPartitions {
+		partitionDatum := PartitionDatum{
+			ID:          partition.ID,
+			Name:        partition.Name,
+			DNSSuffix:   partition.DNSSuffix,
+			RegionRegex: partition.RegionRegex,
 		}
-	} else {
-		g.Fatalf("can't parse endpoints document version")
-	}
 
-	/*
-		See https://github.com/aws/aws-sdk-go/blob/main/aws/endpoints/v3model.go.
-		e.g.
-		{
-		  "partitions": [{
-		    "partition": "aws",
-		    "partitionName": "AWS Standard",
-		    "regions" : {
-		      "af-south-1" : {
-		        "description" : "Africa (Cape Town)"
-		      },
-			  ...
-		    },
-		    "services" : {
-		      "access-analyzer" : {
-		        "endpoints" : {
-		          "af-south-1" : { },
-				  ...
-		        },
-		      },
-		      ...
-		    },
-			...
-		   }, ...]
-		}
-	*/
-	if partitions, ok := target["partitions"].([]any); ok {
-		for _, partition := range partitions {
-			if partition, ok := partition.(map[string]any); ok {
-				partitionDatum := PartitionDatum{}
-
-				if id, ok := partition["partition"].(string); ok {
-					partitionDatum.ID = id
-				}
-				if name, ok := partition["partitionName"].(string); ok {
-					partitionDatum.Name = name
-				}
-				if dnsSuffix, ok := partition["dnsSuffix"].(string); ok {
-					partitionDatum.DNSSuffix = dnsSuffix
-				}
-				if regionRegex, ok := partition["regionRegex"].(string); ok {
-					partitionDatum.RegionRegex = regionRegex
-				}
-				if regions, ok := partition["regions"].(map[string]any); ok {
-					for id, region := range regions {
-						regionDatum := RegionDatum{
-							ID: id,
-						}
-
-						if region, ok := region.(map[string]any); ok {
-							if description, ok := region["description"].(string); ok {
-								regionDatum.Description = description
-							}
-						}
-
-						partitionDatum.Regions = append(partitionDatum.Regions, regionDatum)
-					}
+		if outputs := partitionsByID[partition.ID].Outputs; outputs != nil {
+			partitionDatum.DualStackDNSSuffix = outputs.DualStackDNSSuffix
+			partitionDatum.ImplicitGlobalRegion = outputs.ImplicitGlobalRegion
+			partitionDatum.SupportsDualStack = outputs.SupportsDualStack
+			partitionDatum.SupportsFIPS = outputs.SupportsFIPS
+		}
+		partitionDatum.ServicePrincipalDNSSuffix = servicePrincipalDNSSuffix
+		for _, service := range partitionDNSSuffixServicePrincipals[partitionDatum.ID] {
+			if partitionDatum.ServicePrincipalDNSSuffixes == nil {
+				partitionDatum.ServicePrincipalDNSSuffixes = make(map[string]string)
+			}
+			partitionDatum.ServicePrincipalDNSSuffixes[service] = partitionDatum.DNSSuffix
+		}
+
+		// Regions are also taken from the partitions document, so that any missing from the endpoints document are reported.
+		regions := maps.Clone(partitionsByID[partition.ID].Regions)
+		if regions == nil {
+			regions = make(map[string]endpointsdoc.Region)
+		}
+		maps.Copy(regions, partition.Regions)
+		for id, region := range regions {
+			partitionDatum.Regions = append(partitionDatum.Regions, RegionDatum{
+				ID:              id,
+				Description:     region.Description,
+				Geography:       geographyForRegion(id),
+				OptInRequired:   optInRegions[id],
+				LocalZoneParent: localZoneParentRegions[id],
+			})
+		}
+
+		for id, service := range partition.Services {
+			serviceDatum := ServiceDatum{
+				ID:                id,
+				PartitionEndpoint: service.PartitionEndpoint,
+			}
+
+			for region, endpoint := range service.Endpoints {
+				endpointDatum := EndpointDatum{
+					ID:                     region,
+					Hostname:               endpoint.Hostname,
+					Deprecated:             endpoint.Deprecated,
+					CredentialScopeRegion:  endpoint.CredentialScope.Region,
+					CredentialScopeService: endpoint.CredentialScope.Service,
 				}
-				if services, ok := partition["services"].(map[string]any); ok {
-					for id := range services {
-						serviceDatum := ServiceDatum{
-							ID: id,
-						}
-
-						partitionDatum.Services = append(partitionDatum.Services, serviceDatum)
-					}
+				for variant, hostname := range endpoint.Variants {
+					endpointDatum.Variants = append(endpointDatum.Variants, VariantDatum{
+						Expr:     variantExpr(variant),
+						Hostname: hostname,
+					})
 				}
+				sort.SliceStable(endpointDatum.Variants, func(i, j int) bool {
+					return endpointDatum.Variants[i].Expr < endpointDatum.Variants[j].Expr
+				})
 
-				td.Partitions = append(td.Partitions, partitionDatum)
+				serviceDatum.Endpoints = append(serviceDatum.Endpoints, endpointDatum)
 			}
+
+			sort.SliceStable(serviceDatum.Endpoints, func(i, j int) bool {
+				return serviceDatum.Endpoints[i].ID < serviceDatum.Endpoints[j].ID
+			})
+
+			partitionDatum.Services = append(partitionDatum.Services, serviceDatum)
 		}
+
+		td.Partitions = append(td.Partitions, partitionDatum)
 	}
 
 	sort.SliceStable(td.Partitions, func(i, j int) bool {
@@ -182,6 +270,27 @@ func main() {
 		})
 	}
 
+	if regions := regionsWithoutEndpoints(td); len(regions) > 0 {
+		g.Fatalf("no service endpoints for Regions: %s", strings.Join(regions, ", "))
+	}
+
+	if current, err := readGeneratedSummary(filename); err != nil {
+		g.Warnf("unable to compare with current %s: %s", filename, err)
+	} else {
+		added, removed := current.diff(newSummary(td))
+		for _, v := range added {
+			g.Infof("Added: %s", v)
+		}
+		for _, v := range removed {
+			g.Warnf("Removed: %s", v)
+		}
+		g.Infof("%d added, %d removed", len(added), len(removed))
+
+		if len(removed) > 0 && !*allowRemovals {
+			g.Fatalf("%d Regions or services removed from %s, run with -allow-removals to accept", len(removed), filename)
+		}
+	}
+
 	d := g.NewGoFileDestination(filename)
 
 	if err := d.WriteTemplate("endpoints", tmpl, td, templateFuncMap); err != nil {
@@ -193,14 +302,312 @@ func main() {
 	}
 }
 
-func readHTTPJSON(url string, to any) error {
-	r, err := http.Get(url)
+// variantExpr returns the Go expression for an endpoint variant, e.g. "DualStackVariant | FIPSVariant".
+func variantExpr(v endpointsdoc.Variant) string {
+	var names []string
+	if v&endpointsdoc.DualStackVariant != 0 {
+		names = append(names, "DualStackVariant")
+	}
+	if v&endpointsdoc.FIPSVariant != 0 {
+		names = append(names, "FIPSVariant")
+	}
+
+	return strings.Join(names, " | ")
+}
+
+// Partition metadata not included in the endpoints document.
+var (
+	// Service principals use the same base domain name in all partitions,
+	// except for the following services' principals, which use the partition's DNS suffix.
+	// See https://github.com/aws/aws-cdk/blob/main/packages/aws-cdk-lib/region-info/lib/default.ts.
+	servicePrincipalDNSSuffix           = "amazonaws.com"
+	partitionDNSSuffixServicePrincipals = map[string][]string{
+		"aws-cn":    {"codedeploy", "ec2", "elasticmapreduce", "logs", "s3"},
+		"aws-iso":   {"cloudhsm", "config", "logs", "workspaces"},
+		"aws-iso-b": {"dms", "logs"},
+	}
+)
+
+// Region metadata not included in the endpoints document.
+var (
+	// Regions launched after March 20, 2019 are disabled by default.
+	// See https://docs.aws.amazon.com/accounts/latest/reference/manage-acct-regions.html.
+	optInRegions = map[string]bool{
+		"af-south-1":     true,
+		"ap-east-1":      true,
+		"ap-east-2":      true,
+		"ap-south-2":     true,
+		"ap-southeast-3": true,
+		"ap-southeast-4": true,
+		"ap-southeast-5": true,
+		"ap-southeast-6": true,
+		"ap-southeast-7": true,
+		"ca-west-1":      true,
+		"eu-central-2":   true,
+		"eu-south-1":     true,
+		"eu-south-2":     true,
+		"il-central-1":   true,
+		"me-central-1":   true,
+		"me-south-1":     true,
+		"mx-central-1":   true,
+	}
+
+	// See https://docs.aws.amazon.com/local-zones/latest/ug/available-local-zones.html.
+	localZoneParentRegions = map[string]bool{
+		"af-south-1":     true,
+		"ap-northeast-1": true,
+		"ap-south-1":     true,
+		"ap-southeast-1": true,
+		"ap-southeast-2": true,
+		"eu-central-1":   true,
+		"eu-north-1":     true,
+		"me-south-1":     true,
+		"us-east-1":      true,
+		"us-west-2":      true,
+	}
+)
+
+// geographyForRegion returns the name of the endpoints.Geography constant for a Region based on the prefix of its ID.
+func geographyForRegion(id string) string {
+	prefix, _, _ := strings.Cut(id, "-")
+
+	switch prefix {
+	case "af":
+		return "GeographyAfrica"
+	case "ap", "cn":
+		return "GeographyAsiaPacific"
+	case "eu":
+		return "GeographyEurope"
+	case "il", "me":
+		return "GeographyMiddleEast"
+	case "ca", "mx", "us":
+		return "GeographyNorthAmerica"
+	case "sa":
+		return "GeographySouthAmerica"
+	default:
+		return "GeographyUnknown"
+	}
+}
+
+// regionsWithoutEndpoints returns the Regions, as "{partition}/{id}", for which no service has an endpoint.
+func regionsWithoutEndpoints(td TemplateData) []string {
+	var regions []string
+
+	for _, partition := range td.Partitions {
+		covered := make(map[string]bool)
+		for _, service := range partition.Services {
+			for _, endpoint := range service.Endpoints {
+				covered[endpoint.ID] = true
+			}
+		}
+		for _, region := range partition.Regions {
+			if !covered[region.ID] {
+				regions = append(regions, partition.ID+"/"+region.ID)
+			}
+		}
+	}
+
+	return regions
+}
+
+const stdinSource = "-"
+
+// readJSON decodes the JSON document read from an HTTP(S) URL, a local file or standard input and returns its SHA-256 checksum.
+// If an expected checksum is specified, the document's checksum must match.
+func readJSON(source, expectedChecksum string, to any) (string, error) {
+	body, err := readSource(source)
 	if err != nil {
-		return err
+		return "", err
+	}
+
+	sum := sha256.Sum256(body)
+	checksum := hex.EncodeToString(sum[:])
+
+	if expectedChecksum != "" && !strings.EqualFold(checksum, expectedChecksum) {
+		return "", fmt.Errorf("checksum mismatch: expected %s, got %s", expectedChecksum, checksum)
+	}
+
+	return checksum, decodeFromReader(bytes.NewReader(body), to)
+}
+
+func readSource(source string) ([]byte, error) {
+	switch {
+	case source == stdinSource:
+		return io.ReadAll(os.Stdin)
+
+	case strings.HasPrefix(source, "http://"), strings.HasPrefix(source, "https://"):
+		r, err := http.Get(source)
+		if err != nil {
+			return nil, err
+		}
+		defer r.Body.Close()
+
+		if r.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected HTTP status: %s", r.Status)
+		}
+
+		return io.ReadAll(r.Body)
+
+	default:
+		return os.ReadFile(strings.TrimPrefix(source, "file://"))
+	}
+}
+
+// sourceName returns the name of a source recorded in the generated file.
+// Local file paths are reduced to the file name so that the generated file doesn't depend on the build environment.
+func sourceName(source string) string {
+	switch {
+	case source == stdinSource:
+		return "stdin"
+	case strings.HasPrefix(source, "http://"), strings.HasPrefix(source, "https://"):
+		return source
+	default:
+		return filepath.Base(strings.TrimPrefix(source, "file://"))
+	}
+}
+
+// summary is the set of Regions and services in each partition, as "{partition}/region/{id}" and "{partition}/service/{id}".
+type summary map[string]struct{}
+
+func newSummary(td TemplateData) summary {
+	s := make(summary)
+
+	for _, partition := range td.Partitions {
+		for _, region := range partition.Regions {
+			s.addRegion(partition.ID, region.ID)
+		}
+		for _, service := range partition.Services {
+			s.addService(partition.ID, service.ID)
+		}
+	}
+
+	return s
+}
+
+func (s summary) addRegion(partitionID, regionID string) {
+	s[partitionID+"/region/"+regionID] = struct{}{}
+}
+
+func (s summary) addService(partitionID, serviceID string) {
+	s[partitionID+"/service/"+serviceID] = struct{}{}
+}
+
+// diff returns the sorted entries added in and removed from other.
+func (s summary) diff(other summary) ([]string, []string) {
+	var added, removed []string
+
+	for k := range other {
+		if _, ok := s[k]; !ok {
+			added = append(added, k)
+		}
+	}
+	for k := range s {
+		if _, ok := other[k]; !ok {
+			removed = append(removed, k)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+
+	return added, removed
+}
+
+// readGeneratedSummary returns the summary of a previously generated file.
+// A missing file has an empty summary.
+func readGeneratedSummary(filename string) (summary, error) {
+	s := make(summary)
+
+	f, err := parser.ParseFile(token.NewFileSet(), filename, nil, 0)
+	if os.IsNotExist(err) {
+		return s, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	// Values of the partition and Region ID constants.
+	consts := make(map[string]string)
+	var partitions *ast.CompositeLit
+
+	ast.Inspect(f, func(n ast.Node) bool {
+		spec, ok := n.(*ast.ValueSpec)
+		if !ok {
+			return true
+		}
+		for i, name := range spec.Names {
+			if i >= len(spec.Values) {
+				break
+			}
+			switch v := spec.Values[i].(type) {
+			case *ast.BasicLit:
+				if v.Kind == token.STRING {
+					consts[name.Name], _ = strconv.Unquote(v.Value)
+				}
+			case *ast.CompositeLit:
+				if name.Name == "partitions" {
+					partitions = v
+				}
+			}
+		}
+		return false
+	})
+
+	if partitions == nil {
+		return nil, fmt.Errorf("partitions not found")
+	}
+
+	// key returns the value of a map key, either a string literal or a constant.
+	key := func(expr ast.Expr) string {
+		switch v := expr.(type) {
+		case *ast.BasicLit:
+			s, _ := strconv.Unquote(v.Value)
+			return s
+		case *ast.Ident:
+			return consts[v.Name]
+		}
+		return ""
+	}
+
+	for _, elt := range partitions.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		partitionID := key(kv.Key)
+		partition, ok := kv.Value.(*ast.CompositeLit)
+		if !ok {
+			continue
+		}
+
+		for _, elt := range partition.Elts {
+			field, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			name, ok := field.Key.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			m, ok := field.Value.(*ast.CompositeLit)
+			if !ok {
+				continue
+			}
+
+			for _, elt := range m.Elts {
+				kv, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+				switch name.Name {
+				case "regions":
+					s.addRegion(partitionID, key(kv.Key))
+				case "services":
+					s.addService(partitionID, key(kv.Key))
+				}
+			}
+		}
 	}
-	defer r.Body.Close()
 
-	return decodeFromReader(r.Body, to)
+	return s, nil
 }
 
 func decodeFromReader(r io.Reader, to any) error {
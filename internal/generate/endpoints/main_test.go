@@ -0,0 +1,395 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build generate
+// +build generate
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReadJSON(t *testing.T) {
+	t.Parallel()
+
+	const (
+		document = `{"version": 3}`
+		// SHA-256 checksum of document.
+		checksum = "d916c65e2a446edc10bddda4dc2a5762f1a985979fe072208ff4ecc89f0d5cf3"
+	)
+
+	source := filepath.Join(t.TempDir(), "endpoints.json")
+	if err := os.WriteFile(source, []byte(document), 0600); err != nil {
+		t.Fatalf("writing %s: %s", source, err)
+	}
+
+	testcases := map[string]struct {
+		Source           string
+		ExpectedChecksum string
+		ExpectedErr      string
+	}{
+		"no expected checksum": {
+			Source: source,
+		},
+		"matching checksum": {
+			Source:           source,
+			ExpectedChecksum: checksum,
+		},
+		"matching checksum upper case": {
+			Source:           "file://" + source,
+			ExpectedChecksum: strings.ToUpper(checksum),
+		},
+		"checksum mismatch": {
+			Source:           source,
+			ExpectedChecksum: strings.Repeat("0", len(checksum)),
+			ExpectedErr:      "checksum mismatch: expected " + strings.Repeat("0", len(checksum)) + ", got " + checksum,
+		},
+		"missing file": {
+			Source:      filepath.Join(t.TempDir(), "missing.json"),
+			ExpectedErr: "no such file or directory",
+		},
+	}
+
+	for name, testcase := range testcases {
+		testcase := testcase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			target := map[string]any{}
+			got, err := readJSON(testcase.Source, testcase.ExpectedChecksum, &target)
+
+			if testcase.ExpectedErr != "" {
+				if err == nil {
+					t.Fatalf("expected error containing %q, got none", testcase.ExpectedErr)
+				}
+				if !strings.Contains(err.Error(), testcase.ExpectedErr) {
+					t.Fatalf("expected error containing %q, got %q", testcase.ExpectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got != checksum {
+				t.Errorf("expected checksum %s, got %s", checksum, got)
+			}
+			if diff := cmp.Diff(map[string]any{"version": 3.0}, target); diff != "" {
+				t.Errorf("unexpected document (+wanted, -got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestSourceName(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]struct {
+		Source   string
+		Expected string
+	}{
+		"stdin": {
+			Source:   stdinSource,
+			Expected: "stdin",
+		},
+		"URL": {
+			Source:   "https://raw.githubusercontent.com/aws/aws-sdk-go/v1.55.5/models/endpoints/endpoints.json",
+			Expected: "https://raw.githubusercontent.com/aws/aws-sdk-go/v1.55.5/models/endpoints/endpoints.json",
+		},
+		"file path": {
+			Source:   "/tmp/sources/endpoints.json",
+			Expected: "endpoints.json",
+		},
+		"file URL": {
+			Source:   "file:///tmp/sources/partitions.json",
+			Expected: "partitions.json",
+		},
+	}
+
+	for name, testcase := range testcases {
+		testcase := testcase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := sourceName(testcase.Source); got != testcase.Expected {
+				t.Errorf("expected %q, got %q", testcase.Expected, got)
+			}
+		})
+	}
+}
+
+func TestSummaryDiff(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]struct {
+		Current         TemplateData
+		Generated       TemplateData
+		ExpectedAdded   []string
+		ExpectedRemoved []string
+	}{
+		"empty": {},
+		"unchanged": {
+			Current: TemplateData{
+				Partitions: []PartitionDatum{
+					{
+						ID:       "aws",
+						Regions:  []RegionDatum{{ID: "us-east-1"}},
+						Services: []ServiceDatum{{ID: "s3"}},
+					},
+				},
+			},
+			Generated: TemplateData{
+				Partitions: []PartitionDatum{
+					{
+						ID:       "aws",
+						Regions:  []RegionDatum{{ID: "us-east-1"}},
+						Services: []ServiceDatum{{ID: "s3"}},
+					},
+				},
+			},
+		},
+		"no current file": {
+			Generated: TemplateData{
+				Partitions: []PartitionDatum{
+					{
+						ID:       "aws",
+						Regions:  []RegionDatum{{ID: "us-east-1"}},
+						Services: []ServiceDatum{{ID: "s3"}},
+					},
+				},
+			},
+			ExpectedAdded: []string{"aws/region/us-east-1", "aws/service/s3"},
+		},
+		"added and removed": {
+			Current: TemplateData{
+				Partitions: []PartitionDatum{
+					{
+						ID:       "aws",
+						Regions:  []RegionDatum{{ID: "us-east-1"}},
+						Services: []ServiceDatum{{ID: "s3"}, {ID: "simpledb"}},
+					},
+					{
+						ID:      "aws-cn",
+						Regions: []RegionDatum{{ID: "cn-north-1"}},
+					},
+				},
+			},
+			Generated: TemplateData{
+				Partitions: []PartitionDatum{
+					{
+						ID:       "aws",
+						Regions:  []RegionDatum{{ID: "us-east-1"}, {ID: "mx-central-1"}},
+						Services: []ServiceDatum{{ID: "s3"}},
+					},
+				},
+			},
+			ExpectedAdded:   []string{"aws/region/mx-central-1"},
+			ExpectedRemoved: []string{"aws-cn/region/cn-north-1", "aws/service/simpledb"},
+		},
+		"same ID in different partition": {
+			Current: TemplateData{
+				Partitions: []PartitionDatum{
+					{
+						ID:       "aws",
+						Services: []ServiceDatum{{ID: "s3"}},
+					},
+				},
+			},
+			Generated: TemplateData{
+				Partitions: []PartitionDatum{
+					{
+						ID:       "aws-us-gov",
+						Services: []ServiceDatum{{ID: "s3"}},
+					},
+				},
+			},
+			ExpectedAdded:   []string{"aws-us-gov/service/s3"},
+			ExpectedRemoved: []string{"aws/service/s3"},
+		},
+	}
+
+	for name, testcase := range testcases {
+		testcase := testcase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			added, removed := newSummary(testcase.Current).diff(newSummary(testcase.Generated))
+
+			if diff := cmp.Diff(testcase.ExpectedAdded, added); diff != "" {
+				t.Errorf("unexpected added (+wanted, -got): %s", diff)
+			}
+			if diff := cmp.Diff(testcase.ExpectedRemoved, removed); diff != "" {
+				t.Errorf("unexpected removed (+wanted, -got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestRegionsWithoutEndpoints(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]struct {
+		Data     TemplateData
+		Expected []string
+	}{
+		"empty": {},
+		"all Regions have endpoints": {
+			Data: TemplateData{
+				Partitions: []PartitionDatum{
+					{
+						ID:      "aws",
+						Regions: []RegionDatum{{ID: "us-east-1"}, {ID: "us-west-2"}},
+						Services: []ServiceDatum{
+							{ID: "ec2", Endpoints: []EndpointDatum{{ID: "us-east-1"}}},
+							{ID: "s3", Endpoints: []EndpointDatum{{ID: "us-west-2"}}},
+						},
+					},
+				},
+			},
+		},
+		"Regions without endpoints": {
+			Data: TemplateData{
+				Partitions: []PartitionDatum{
+					{
+						ID:      "aws",
+						Regions: []RegionDatum{{ID: "ap-southeast-5"}, {ID: "us-east-1"}},
+						Services: []ServiceDatum{
+							{ID: "ec2", Endpoints: []EndpointDatum{{ID: "us-east-1"}}},
+						},
+					},
+					{
+						ID:      "aws-cn",
+						Regions: []RegionDatum{{ID: "cn-north-1"}},
+					},
+				},
+			},
+			Expected: []string{"aws/ap-southeast-5", "aws-cn/cn-north-1"},
+		},
+		"endpoint in another partition": {
+			Data: TemplateData{
+				Partitions: []PartitionDatum{
+					{
+						ID: "aws",
+						Services: []ServiceDatum{
+							{ID: "ec2", Endpoints: []EndpointDatum{{ID: "us-gov-west-1"}}},
+						},
+					},
+					{
+						ID:      "aws-us-gov",
+						Regions: []RegionDatum{{ID: "us-gov-west-1"}},
+					},
+				},
+			},
+			Expected: []string{"aws-us-gov/us-gov-west-1"},
+		},
+	}
+
+	for name, testcase := range testcases {
+		testcase := testcase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(testcase.Expected, regionsWithoutEndpoints(testcase.Data)); diff != "" {
+				t.Errorf("unexpected Regions (+wanted, -got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestReadGeneratedSummary(t *testing.T) {
+	t.Parallel()
+
+	const generated = `package endpoints
+
+const (
+	AwsPartitionID = "aws"
+)
+
+const (
+	UsEast1RegionID = "us-east-1"
+)
+
+var partitions = map[string]Partition{
+	AwsPartitionID: {
+		id: AwsPartitionID,
+		regions: map[string]Region{
+			UsEast1RegionID: {
+				id: UsEast1RegionID,
+			},
+			"us-west-2": {
+				id: "us-west-2",
+			},
+		},
+		services: map[string]Service{
+			"s3": {
+				id: "s3",
+			},
+		},
+	},
+}
+`
+
+	testcases := map[string]struct {
+		Contents    *string
+		Expected    summary
+		ExpectedErr string
+	}{
+		"missing file": {
+			Expected: summary{},
+		},
+		"generated file": {
+			Contents: aws.String(generated),
+			Expected: summary{
+				"aws/region/us-east-1": {},
+				"aws/region/us-west-2": {},
+				"aws/service/s3":       {},
+			},
+		},
+		"no partitions": {
+			Contents:    aws.String("package endpoints\n"),
+			ExpectedErr: "partitions not found",
+		},
+	}
+
+	for name, testcase := range testcases {
+		testcase := testcase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			filename := filepath.Join(t.TempDir(), "endpoints_gen.go")
+			if testcase.Contents != nil {
+				if err := os.WriteFile(filename, []byte(*testcase.Contents), 0600); err != nil {
+					t.Fatalf("writing %s: %s", filename, err)
+				}
+			}
+
+			got, err := readGeneratedSummary(filename)
+
+			if testcase.ExpectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), testcase.ExpectedErr) {
+					t.Fatalf("expected error containing %q, got %v", testcase.ExpectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(testcase.Expected, got); diff != "" {
+				t.Errorf("unexpected summary (+wanted, -got): %s", diff)
+			}
+		})
+	}
+}
@@ -4,23 +4,17 @@
 package awsbase
 
 import (
-	"bufio"
-	"bytes"
 	"context"
 	"fmt"
-	"io"
 	"log"
 	"net/http"
-	"net/textproto"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
-	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
-	"github.com/aws/aws-sdk-go-v2/service/sqs"
 	smithylogging "github.com/aws/smithy-go/logging"
 	"github.com/aws/smithy-go/middleware"
 	smithyhttp "github.com/aws/smithy-go/transport/http"
@@ -73,6 +67,18 @@ func (l *logAttributeExtractor) HandleInitialize(ctx context.Context, in middlew
 	out middleware.InitializeOutput, metadata middleware.Metadata, err error) {
 	logger := logging.RetrieveLogger(ctx)
 
+	for _, attribute := range operationAttributes(ctx, in) {
+		ctx = logger.SetField(ctx, string(attribute.Key), attribute.Value.AsInterface())
+	}
+
+	ctx = registerResolvedEndpoint(ctx)
+
+	return next.HandleInitialize(ctx, in)
+}
+
+// operationAttributes returns the attributes describing the AWS operation being invoked,
+// including service-specific attributes of the operation input.
+func operationAttributes(ctx context.Context, in middleware.InitializeInput) []attribute.KeyValue {
 	region := awsmiddleware.GetRegion(ctx)
 	serviceID := awsmiddleware.GetServiceID(ctx)
 
@@ -84,27 +90,21 @@ func (l *logAttributeExtractor) HandleInitialize(ctx context.Context, in middlew
 		awsSDKv2Attr(),
 	}
 
-	setters := map[string]otelaws.AttributeSetter{
-		dynamodb.ServiceID: otelaws.DynamoDBAttributeSetter,
-		s3.ServiceID:       s3AttributeSetter,
-		sqs.ServiceID:      otelaws.SQSAttributeSetter,
-	}
-	if setter, ok := setters[serviceID]; ok {
+	for _, setter := range attributeSettersFor(serviceID) {
 		attributes = append(attributes, setter(ctx, in)...)
 	}
 
-	for _, attribute := range attributes {
-		ctx = logger.SetField(ctx, string(attribute.Key), attribute.Value.AsInterface())
-	}
-
-	return next.HandleInitialize(ctx, in)
+	return attributes
 }
 
 // Replaces the built-in logging middleware from https://github.com/aws/smithy-go/blob/main/transport/http/middleware_http_logging.go
 // We want access to the request and response structs, and cannot get it from the built-in.
 // The typical route of adding logging to the http.RoundTripper doesn't work for the AWS SDK for Go v2 without forcing us to manually implement
 // configuration that the SDK handles for us.
-type requestResponseLogger struct{}
+type requestResponseLogger struct {
+	maxRequestBodyLen  int
+	maxResponseBodyLen int
+}
 
 // ID is the middleware identifier.
 func (r *requestResponseLogger) ID() string {
@@ -117,13 +117,22 @@ func (r *requestResponseLogger) HandleDeserialize(ctx context.Context, in middle
 ) {
 	logger := logging.RetrieveLogger(ctx)
 
+	// The request and response are only logged at Debug level, so skip building and decomposing them if it is not output.
+	if !logging.Enabled(ctx, logger, logging.LevelDebug) {
+		return next.HandleDeserialize(ctx, in)
+	}
+
+	ctx = logging.WithBodyLogLimits(ctx, r.maxRequestBodyLen, r.maxResponseBodyLen)
+
 	region := awsmiddleware.GetRegion(ctx)
 
-	if signingRegion := awsmiddleware.GetSigningRegion(ctx); signingRegion != region { //nolint:staticcheck // Not retrievable elsewhere
-		ctx = logger.SetField(ctx, string(logging.SigningRegionKey), signingRegion)
-	}
-	if awsmiddleware.GetEndpointSource(ctx) == aws.EndpointSourceCustom {
-		ctx = logger.SetField(ctx, string(logging.CustomEndpointKey), true)
+	if endpoint := retrieveResolvedEndpoint(ctx); endpoint != nil {
+		for k, v := range endpoint.fields() {
+			ctx = logger.SetField(ctx, k, v)
+		}
+		if endpoint.signingRegion != "" && endpoint.signingRegion != region {
+			ctx = logger.SetField(ctx, string(logging.SigningRegionKey), endpoint.signingRegion)
+		}
 	}
 
 	smithyRequest, ok := in.Request.(*smithyhttp.Request)
@@ -176,7 +185,9 @@ func decomposeHTTPResponse(ctx context.Context, resp *http.Response, elapsed tim
 
 	attributes = append(attributes, logging.DecomposeResponseHeaders(resp)...)
 
-	bodyLogger := responseBodyLogger(ctx)
+	attributes = logging.RedactionRuleForContext(ctx).RedactAttributes(attributes)
+
+	bodyLogger := logging.ResponseBodyLoggerFor(ctx)
 	err := bodyLogger.Log(ctx, resp, &attributes)
 	if err != nil {
 		return nil, err
@@ -190,41 +201,6 @@ func decomposeHTTPResponse(ctx context.Context, resp *http.Response, elapsed tim
 	return result, nil
 }
 
-func responseBodyLogger(ctx context.Context) logging.ResponseBodyLogger {
-	if awsmiddleware.GetServiceID(ctx) == "S3" {
-		if op := awsmiddleware.GetOperationName(ctx); op == "GetObject" {
-			return &logging.S3ObjectResponseBodyLogger{}
-		}
-	}
-
-	return &defaultResponseBodyLogger{}
-}
-
-var _ logging.ResponseBodyLogger = &defaultResponseBodyLogger{}
-
-type defaultResponseBodyLogger struct{}
-
-func (l *defaultResponseBodyLogger) Log(ctx context.Context, resp *http.Response, attrs *[]attribute.KeyValue) error {
-	content, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
-
-	// Restore the body reader
-	resp.Body = io.NopCloser(bytes.NewBuffer(content))
-
-	reader := textproto.NewReader(bufio.NewReader(bytes.NewReader(content)))
-
-	body, err := logging.ReadTruncatedBody(reader, logging.MaxResponseBodyLen)
-	if err != nil {
-		return err
-	}
-
-	*attrs = append(*attrs, attribute.String("http.response.body", body))
-
-	return nil
-}
-
 // May be contributed to go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws
 // See: https://github.com/open-telemetry/opentelemetry-go-contrib/issues/4321
 func s3AttributeSetter(ctx context.Context, in middleware.InitializeInput) []attribute.KeyValue {
@@ -0,0 +1,159 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package awsbase
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/hashicorp/aws-sdk-go-base/v2/logging"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestRequestResponseLoggerDebugDisabled(t *testing.T) {
+	const body = `{"TableName":"test-table"}`
+
+	testcases := map[string]struct {
+		logger             func(ctx context.Context) (context.Context, logging.Logger)
+		expectedDecomposed bool
+	}{
+		"debug enabled": {
+			logger: func(ctx context.Context) (context.Context, logging.Logger) {
+				return logging.NewTfLogger(tflogtest.RootLogger(ctx, io.Discard))
+			},
+			expectedDecomposed: true,
+		},
+		"debug disabled": {
+			logger: func(ctx context.Context) (context.Context, logging.Logger) {
+				return logging.NewHcLogger(ctx, hclog.New(&hclog.LoggerOptions{Level: hclog.Info, Output: io.Discard}))
+			},
+			expectedDecomposed: false,
+		},
+	}
+
+	for name, testcase := range testcases {
+		testcase := testcase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, logger := testcase.logger(context.Background())
+			ctx = logging.RegisterLogger(ctx, logger)
+
+			requestBody := strings.NewReader(body)
+			responseBody := io.NopCloser(strings.NewReader(body))
+
+			var nextRequestBody io.Reader
+			next := middleware.DeserializeHandlerFunc(func(ctx context.Context, in middleware.DeserializeInput) (middleware.DeserializeOutput, middleware.Metadata, error) {
+				nextRequestBody = in.Request.(*smithyhttp.Request).GetStream()
+
+				return middleware.DeserializeOutput{
+					RawResponse: &smithyhttp.Response{
+						Response: &http.Response{
+							StatusCode: http.StatusOK,
+							Header: http.Header{
+								"Content-Type": []string{"application/x-amz-json-1.0"},
+							},
+							Body: responseBody,
+						},
+					},
+				}, middleware.Metadata{}, nil
+			})
+
+			req := smithyhttp.NewStackRequest().(*smithyhttp.Request)
+			req.Method = http.MethodPost
+			req.URL.Scheme = "https"
+			req.URL.Host = "dynamodb.us-east-1.amazonaws.com"
+			req.Header.Set("Content-Type", "application/x-amz-json-1.0")
+			req, err := req.SetStream(requestBody)
+			if err != nil {
+				t.Fatalf("setting request body: %s", err)
+			}
+
+			m := &requestResponseLogger{}
+			out, _, err := m.HandleDeserialize(ctx, middleware.DeserializeInput{Request: req}, next)
+			if err != nil {
+				t.Fatalf("handling request: %s", err)
+			}
+
+			// Decomposing the request and response replaces their bodies so that the logged prefix can be read.
+			requestDecomposed := nextRequestBody != io.Reader(requestBody)
+			responseDecomposed := out.RawResponse.(*smithyhttp.Response).Body != responseBody
+
+			if requestDecomposed != testcase.expectedDecomposed {
+				t.Errorf("expected request decomposed %t, got %t", testcase.expectedDecomposed, requestDecomposed)
+			}
+			if responseDecomposed != testcase.expectedDecomposed {
+				t.Errorf("expected response decomposed %t, got %t", testcase.expectedDecomposed, responseDecomposed)
+			}
+		})
+	}
+}
+
+func BenchmarkRequestResponseLogger(b *testing.B) {
+	testcases := map[string]hclog.Level{
+		"debug enabled":  hclog.Debug,
+		"debug disabled": hclog.Info,
+	}
+
+	for name, level := range testcases {
+		b.Run(name, func(b *testing.B) {
+			ctx, logger := logging.NewHcLogger(context.Background(), hclog.New(&hclog.LoggerOptions{
+				Level:  level,
+				Output: io.Discard,
+			}))
+			ctx = logging.RegisterLogger(ctx, logger)
+
+			benchmarkRequestResponseLogger(ctx, b)
+		})
+	}
+}
+
+func benchmarkRequestResponseLogger(ctx context.Context, b *testing.B) {
+	const body = `{"TableName":"test-table","Key":{"id":{"S":"AKIAI44QH8DHBEXAMPLE"}}}`
+
+	next := middleware.DeserializeHandlerFunc(func(ctx context.Context, in middleware.DeserializeInput) (middleware.DeserializeOutput, middleware.Metadata, error) {
+		return middleware.DeserializeOutput{
+			RawResponse: &smithyhttp.Response{
+				Response: &http.Response{
+					StatusCode: http.StatusOK,
+					Header: http.Header{
+						"Content-Type":     []string{"application/x-amz-json-1.0"},
+						"X-Amzn-Requestid": []string{"ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"},
+					},
+					Body: io.NopCloser(strings.NewReader(body)),
+				},
+			},
+		}, middleware.Metadata{}, nil
+	})
+
+	m := &requestResponseLogger{}
+
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		req := smithyhttp.NewStackRequest().(*smithyhttp.Request)
+		req.Method = http.MethodPost
+		req.URL.Scheme = "https"
+		req.URL.Host = "dynamodb.us-east-1.amazonaws.com"
+		req.Header.Set("Content-Type", "application/x-amz-json-1.0")
+		req, err := req.SetStream(strings.NewReader(body))
+		if err != nil {
+			b.Fatalf("setting request body: %s", err)
+		}
+
+		out, _, err := m.HandleDeserialize(ctx, middleware.DeserializeInput{Request: req}, next)
+		if err != nil {
+			b.Fatalf("handling request: %s", err)
+		}
+		if _, err := io.Copy(io.Discard, out.RawResponse.(*smithyhttp.Response).Body); err != nil {
+			b.Fatalf("reading response body: %s", err)
+		}
+	}
+}
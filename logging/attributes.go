@@ -9,6 +9,11 @@ const (
 	AwsSdkKey         attribute.Key = "tf_aws.sdk"
 	SigningRegionKey  attribute.Key = "tf_aws.signing_region"
 	CustomEndpointKey attribute.Key = "tf_aws.custom_endpoint"
+
+	EndpointKey             attribute.Key = "tf_aws.endpoint"
+	SigningNameKey          attribute.Key = "tf_aws.signing_name"
+	UseDualStackEndpointKey attribute.Key = "tf_aws.use_dual_stack_endpoint"
+	UseFIPSEndpointKey      attribute.Key = "tf_aws.use_fips_endpoint"
 )
 
 func SigningRegion(region string) attribute.KeyValue {
@@ -4,8 +4,13 @@
 package logging
 
 import (
+	"context"
+	"net/http"
+	"strings"
 	"testing"
 	"unsafe"
+
+	"github.com/hashicorp/go-hclog"
 )
 
 func TestMaskAWSSensitiveValues(t *testing.T) {
@@ -152,3 +157,44 @@ func BenchmarkMaskAWSSensitiveValues(b *testing.B) {
 }
 
 var dump string
+
+func BenchmarkDecomposeHTTPRequest(b *testing.B) {
+	const body = `{"TableName":"test-table","Key":{"id":{"S":"AKIAI44QH8DHBEXAMPLE"}}}`
+
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://dynamodb.us-east-1.amazonaws.com/", strings.NewReader(body))
+		if err != nil {
+			b.Fatalf("creating request: %s", err)
+		}
+		req.Header.Set("Content-Type", "application/x-amz-json-1.0")
+
+		if _, err := DecomposeHTTPRequest(ctx, req); err != nil {
+			b.Fatalf("decomposing request: %s", err)
+		}
+	}
+}
+
+func BenchmarkEnabled(b *testing.B) {
+	testcases := map[string]Logger{
+		"HcLogger":   HcLogger{},
+		"NullLogger": NullLogger{},
+		"TfLogger":   TfLogger(""),
+	}
+
+	for name, logger := range testcases {
+		b.Run(name, func(b *testing.B) {
+			ctx := context.Background()
+			if _, ok := logger.(HcLogger); ok {
+				ctx, _ = NewHcLogger(ctx, hclog.NewNullLogger())
+			}
+
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				Enabled(ctx, logger, LevelDebug)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package logging
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+)
+
+type bodyLogLimitsKeyT string
+
+const bodyLogLimitsKey bodyLogLimitsKeyT = "body-log-limits"
+
+type bodyLogLimits struct {
+	request  int
+	response int
+}
+
+// WithBodyLogLimits returns a context which sets the maximum lengths of logged HTTP request and response bodies.
+// If a limit is not positive, the default is used.
+func WithBodyLogLimits(ctx context.Context, requestLen, responseLen int) context.Context {
+	return context.WithValue(ctx, bodyLogLimitsKey, bodyLogLimits{
+		request:  requestLen,
+		response: responseLen,
+	})
+}
+
+// RequestBodyLogLimit returns the maximum length of logged HTTP request bodies.
+func RequestBodyLogLimit(ctx context.Context) int {
+	if limits, ok := ctx.Value(bodyLogLimitsKey).(bodyLogLimits); ok && limits.request > 0 {
+		return limits.request
+	}
+	return maxRequestBodyLen
+}
+
+// ResponseBodyLogLimit returns the maximum length of logged HTTP response bodies.
+func ResponseBodyLogLimit(ctx context.Context) int {
+	if limits, ok := ctx.Value(bodyLogLimitsKey).(bodyLogLimits); ok && limits.response > 0 {
+		return limits.response
+	}
+	return MaxResponseBodyLen
+}
+
+var bodyLoggers = struct {
+	mu       sync.RWMutex
+	request  map[operation]RequestBodyLogger
+	response map[operation]ResponseBodyLogger
+}{
+	request:  builtinRequestBodyLoggers(),
+	response: builtinResponseBodyLoggers(),
+}
+
+// RegisterRequestBodyLogger registers the RequestBodyLogger used for the given service ID and operation,
+// replacing any logger already registered.
+// If operationName is empty, the logger is used for all operations of the service without a logger of their own.
+func RegisterRequestBodyLogger(serviceID, operationName string, l RequestBodyLogger) {
+	bodyLoggers.mu.Lock()
+	defer bodyLoggers.mu.Unlock()
+
+	bodyLoggers.request[operation{serviceID: serviceID, name: operationName}] = l
+}
+
+// RegisterResponseBodyLogger registers the ResponseBodyLogger used for the given service ID and operation,
+// replacing any logger already registered.
+// If operationName is empty, the logger is used for all operations of the service without a logger of their own.
+func RegisterResponseBodyLogger(serviceID, operationName string, l ResponseBodyLogger) {
+	bodyLoggers.mu.Lock()
+	defer bodyLoggers.mu.Unlock()
+
+	bodyLoggers.response[operation{serviceID: serviceID, name: operationName}] = l
+}
+
+// RequestBodyLoggerFor returns the RequestBodyLogger for the AWS operation in the context.
+func RequestBodyLoggerFor(ctx context.Context) RequestBodyLogger {
+	serviceID, operationName := operationFromContext(ctx)
+
+	bodyLoggers.mu.RLock()
+	defer bodyLoggers.mu.RUnlock()
+
+	if l, ok := bodyLoggers.request[operation{serviceID: serviceID, name: operationName}]; ok {
+		return l
+	}
+	if l, ok := bodyLoggers.request[operation{serviceID: serviceID}]; ok {
+		return l
+	}
+	return &defaultRequestBodyLogger{}
+}
+
+// ResponseBodyLoggerFor returns the ResponseBodyLogger for the AWS operation in the context.
+func ResponseBodyLoggerFor(ctx context.Context) ResponseBodyLogger {
+	serviceID, operationName := operationFromContext(ctx)
+
+	bodyLoggers.mu.RLock()
+	defer bodyLoggers.mu.RUnlock()
+
+	if l, ok := bodyLoggers.response[operation{serviceID: serviceID, name: operationName}]; ok {
+		return l
+	}
+	if l, ok := bodyLoggers.response[operation{serviceID: serviceID}]; ok {
+		return l
+	}
+	return &defaultResponseBodyLogger{}
+}
+
+func builtinRequestBodyLoggers() map[operation]RequestBodyLogger {
+	loggers := make(map[operation]RequestBodyLogger)
+	add := func(serviceID string, operationNames ...string) {
+		for _, name := range operationNames {
+			loggers[operation{serviceID: serviceID, name: name}] = &RedactedRequestBodyLogger{}
+		}
+	}
+
+	add("ECR", "UploadLayerPart")
+	add("ECR PUBLIC", "UploadLayerPart")
+	add("Kinesis", "PutRecord", "PutRecords")
+	add("Lambda", "Invoke", "InvokeAsync", "InvokeWithResponseStream")
+	add("S3", "PutObject", "UploadPart")
+
+	return loggers
+}
+
+func builtinResponseBodyLoggers() map[operation]ResponseBodyLogger {
+	loggers := make(map[operation]ResponseBodyLogger)
+	add := func(serviceID string, operationNames ...string) {
+		for _, name := range operationNames {
+			loggers[operation{serviceID: serviceID, name: name}] = &RedactedResponseBodyLogger{}
+		}
+	}
+
+	add("Kinesis", "GetRecords")
+	add("Lambda", "Invoke", "InvokeWithResponseStream")
+	add("S3", "GetObject")
+
+	return loggers
+}
+
+// bodyPeekPadding is the length of a body read in addition to the logged length,
+// allowing the logged body to be truncated on a line boundary.
+const bodyPeekPadding = 1024
+
+// peekBody reads up to n bytes from the start of body.
+// It returns the bytes read and a ReadCloser which reads the complete body, including the bytes read.
+// The remainder of the body is not read, so large or streaming bodies are not buffered.
+func peekBody(body io.ReadCloser, n int) ([]byte, io.ReadCloser, error) {
+	prefix, err := io.ReadAll(io.LimitReader(body, int64(n)))
+
+	return prefix, &prefixedReadCloser{
+		Reader: io.MultiReader(bytes.NewReader(prefix), body),
+		Closer: body,
+	}, err
+}
+
+type prefixedReadCloser struct {
+	io.Reader
+	io.Closer
+}
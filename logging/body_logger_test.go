@@ -0,0 +1,267 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package logging
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestBodyLogLimits(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]struct {
+		ctx              context.Context
+		expectedRequest  int
+		expectedResponse int
+	}{
+		"not set": {
+			ctx:              context.Background(),
+			expectedRequest:  maxRequestBodyLen,
+			expectedResponse: MaxResponseBodyLen,
+		},
+
+		"zero": {
+			ctx:              WithBodyLogLimits(context.Background(), 0, 0),
+			expectedRequest:  maxRequestBodyLen,
+			expectedResponse: MaxResponseBodyLen,
+		},
+
+		"set": {
+			ctx:              WithBodyLogLimits(context.Background(), 10, 20),
+			expectedRequest:  10,
+			expectedResponse: 20,
+		},
+	}
+
+	for name, testcase := range testcases {
+		testcase := testcase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if a, e := RequestBodyLogLimit(testcase.ctx), testcase.expectedRequest; a != e {
+				t.Errorf("expected request limit %d, got %d", e, a)
+			}
+			if a, e := ResponseBodyLogLimit(testcase.ctx), testcase.expectedResponse; a != e {
+				t.Errorf("expected response limit %d, got %d", e, a)
+			}
+		})
+	}
+}
+
+func TestBodyLoggerFor(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]struct {
+		serviceID        string
+		operation        string
+		expectedRequest  RequestBodyLogger
+		expectedResponse ResponseBodyLogger
+	}{
+		"default": {
+			serviceID:        "STS",
+			operation:        "GetCallerIdentity",
+			expectedRequest:  &defaultRequestBodyLogger{},
+			expectedResponse: &defaultResponseBodyLogger{},
+		},
+
+		"S3 PutObject": {
+			serviceID:        "S3",
+			operation:        "PutObject",
+			expectedRequest:  &RedactedRequestBodyLogger{},
+			expectedResponse: &defaultResponseBodyLogger{},
+		},
+
+		"S3 GetObject": {
+			serviceID:        "S3",
+			operation:        "GetObject",
+			expectedRequest:  &defaultRequestBodyLogger{},
+			expectedResponse: &RedactedResponseBodyLogger{},
+		},
+
+		"Lambda Invoke": {
+			serviceID:        "Lambda",
+			operation:        "Invoke",
+			expectedRequest:  &RedactedRequestBodyLogger{},
+			expectedResponse: &RedactedResponseBodyLogger{},
+		},
+
+		"Kinesis PutRecord": {
+			serviceID:        "Kinesis",
+			operation:        "PutRecord",
+			expectedRequest:  &RedactedRequestBodyLogger{},
+			expectedResponse: &defaultResponseBodyLogger{},
+		},
+
+		"ECR UploadLayerPart": {
+			serviceID:        "ECR",
+			operation:        "UploadLayerPart",
+			expectedRequest:  &RedactedRequestBodyLogger{},
+			expectedResponse: &defaultResponseBodyLogger{},
+		},
+	}
+
+	for name, testcase := range testcases {
+		testcase := testcase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := WithOperation(context.Background(), testcase.serviceID, testcase.operation)
+
+			if a, e := RequestBodyLoggerFor(ctx), testcase.expectedRequest; reflect.TypeOf(a) != reflect.TypeOf(e) {
+				t.Errorf("expected request body logger %T, got %T", e, a)
+			}
+			if a, e := ResponseBodyLoggerFor(ctx), testcase.expectedResponse; reflect.TypeOf(a) != reflect.TypeOf(e) {
+				t.Errorf("expected response body logger %T, got %T", e, a)
+			}
+		})
+	}
+}
+
+type testRequestBodyLogger struct{}
+
+func (l *testRequestBodyLogger) Log(ctx context.Context, req *http.Request, attrs *[]attribute.KeyValue) error {
+	*attrs = append(*attrs, attribute.String("http.request.body", "test"))
+	return nil
+}
+
+func TestRegisterRequestBodyLogger(t *testing.T) {
+	t.Parallel()
+
+	const serviceID = "TestRegisterRequestBodyLogger"
+	RegisterRequestBodyLogger(serviceID, "", &testRequestBodyLogger{})
+	RegisterRequestBodyLogger(serviceID, "Operation", &RedactedRequestBodyLogger{})
+
+	if a := RequestBodyLoggerFor(WithOperation(context.Background(), serviceID, "Operation")); reflect.TypeOf(a) != reflect.TypeOf(&RedactedRequestBodyLogger{}) {
+		t.Errorf("expected operation logger, got %T", a)
+	}
+
+	ctx := WithOperation(context.Background(), serviceID, "OtherOperation")
+	if a := RequestBodyLoggerFor(ctx); reflect.TypeOf(a) != reflect.TypeOf(&testRequestBodyLogger{}) {
+		t.Errorf("expected service logger, got %T", a)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://example.com/", strings.NewReader("body"))
+	if err != nil {
+		t.Fatalf("creating request: %s", err)
+	}
+
+	fields, err := DecomposeHTTPRequest(ctx, req)
+	if err != nil {
+		t.Fatalf("decomposing request: %s", err)
+	}
+
+	if a, e := fields["http.request.body"], "test"; a != e {
+		t.Errorf("expected body %q, got %q", e, a)
+	}
+}
+
+func TestDecomposeHTTPRequestBodyLogLimit(t *testing.T) {
+	t.Parallel()
+
+	ctx := WithBodyLogLimits(context.Background(), 10, 0)
+
+	body := strings.Repeat("line\n", 10)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://example.com/", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("creating request: %s", err)
+	}
+
+	fields, err := DecomposeHTTPRequest(ctx, req)
+	if err != nil {
+		t.Fatalf("decomposing request: %s", err)
+	}
+
+	if a, e := fields["http.request.body"], "line\nline\n[truncated...]"; a != e {
+		t.Errorf("expected body %q, got %q", e, a)
+	}
+}
+
+type countingReader struct {
+	io.Reader
+	n int
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.n += n
+	return n, err
+}
+
+func TestDefaultResponseBodyLoggerStreaming(t *testing.T) {
+	t.Parallel()
+
+	content := strings.Repeat("0123456789abcdef\n", 64*1024)
+	source := &countingReader{Reader: strings.NewReader(content)}
+
+	resp := &http.Response{
+		Header: http.Header{"Content-Type": []string{"text/plain"}},
+		Body:   io.NopCloser(source),
+	}
+
+	ctx := WithBodyLogLimits(context.Background(), 0, 64)
+
+	var attrs []attribute.KeyValue
+	if err := (&defaultResponseBodyLogger{}).Log(ctx, resp, &attrs); err != nil {
+		t.Fatalf("logging response body: %s", err)
+	}
+
+	if a, e := source.n, 64+bodyPeekPadding; a > e {
+		t.Errorf("expected at most %d bytes read while logging, got %d", e, a)
+	}
+
+	if l := len(attrs); l != 1 {
+		t.Fatalf("expected 1 attribute, got %d", l)
+	}
+	if a, e := attrs[0].Value.AsString(), strings.Repeat("0123456789abcdef\n", 4)+"[truncated...]"; a != e {
+		t.Errorf("expected logged body %q, got %q", e, a)
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("reading response body: %s", err)
+	}
+	if string(b) != content {
+		t.Errorf("expected complete response body (%d bytes), got %d bytes", len(content), len(b))
+	}
+}
+
+func TestDefaultRequestBodyLoggerStreaming(t *testing.T) {
+	t.Parallel()
+
+	content := strings.Repeat("0123456789abcdef\n", 64*1024)
+	source := &countingReader{Reader: strings.NewReader(content)}
+
+	req, err := http.NewRequest(http.MethodPut, "https://example.com/", io.NopCloser(source))
+	if err != nil {
+		t.Fatalf("creating request: %s", err)
+	}
+
+	ctx := WithBodyLogLimits(context.Background(), 64, 0)
+
+	var attrs []attribute.KeyValue
+	if err := (&defaultRequestBodyLogger{}).Log(ctx, req, &attrs); err != nil {
+		t.Fatalf("logging request body: %s", err)
+	}
+
+	if a, e := source.n, 64+bodyPeekPadding; a > e {
+		t.Errorf("expected at most %d bytes read while logging, got %d", e, a)
+	}
+
+	b, err := io.ReadAll(req.Body)
+	if err != nil {
+		t.Fatalf("reading request body: %s", err)
+	}
+	if string(b) != content {
+		t.Errorf("expected complete request body (%d bytes), got %d bytes", len(content), len(b))
+	}
+}
@@ -12,6 +12,7 @@ import (
 type HcLogger struct{}
 
 var _ Logger = HcLogger{}
+var _ LevelEnabler = HcLogger{}
 
 func NewHcLogger(ctx context.Context, logger hclog.Logger) (context.Context, HcLogger) {
 	ctx = hclog.WithContext(ctx, logger)
@@ -47,6 +48,20 @@ func (l HcLogger) Trace(ctx context.Context, msg string, fields ...map[string]an
 	logger.Trace(msg, flattenFields(fields...)...)
 }
 
+func (l HcLogger) Enabled(ctx context.Context, level Level) bool {
+	logger := hclog.FromContext(ctx)
+	switch level {
+	case LevelTrace:
+		return logger.IsTrace()
+	case LevelDebug:
+		return logger.IsDebug()
+	case LevelInfo:
+		return logger.IsInfo()
+	default:
+		return logger.IsWarn()
+	}
+}
+
 // TODO: how to handle duplicates
 func flattenFields(fields ...map[string]any) []any {
 	var totalLen int
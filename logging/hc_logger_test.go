@@ -21,6 +21,24 @@ func TestHcLoggerSetField(t *testing.T) {
 	testLoggerSetField(t, hclogRootName, hcLoggerFactory)
 }
 
+func TestHcLoggerEnabled(t *testing.T) {
+	ctx, logger := NewHcLogger(context.Background(), hclog.New(&hclog.LoggerOptions{
+		Level:  hclog.Info,
+		Output: io.Discard,
+	}))
+
+	for level, expected := range map[Level]bool{
+		LevelTrace: false,
+		LevelDebug: false,
+		LevelInfo:  true,
+		LevelWarn:  true,
+	} {
+		if a := Enabled(ctx, logger, level); a != expected {
+			t.Errorf("level %d: expected %t, got %t", level, expected, a)
+		}
+	}
+}
+
 func hcLoggerFactory(ctx context.Context, name string, output io.Writer) (context.Context, Logger) {
 	hclogger := configureHcLogger(output)
 
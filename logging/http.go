@@ -11,13 +11,11 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"net/http/httputil"
 	"net/textproto"
 	"regexp"
 	"strconv"
 	"strings"
 
-	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
 	"github.com/hashicorp/aws-sdk-go-base/v2/internal/slices"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/semconv/v1.17.0/httpconv"
@@ -26,8 +24,10 @@ import (
 )
 
 const (
+	// maxRequestBodyLen is the default maximum length of logged HTTP request bodies.
 	maxRequestBodyLen = 1024
 
+	// MaxResponseBodyLen is the default maximum length of logged HTTP response bodies.
 	MaxResponseBodyLen = 4096
 )
 
@@ -42,7 +42,9 @@ func DecomposeHTTPRequest(ctx context.Context, req *http.Request) (map[string]an
 
 	attributes = append(attributes, decomposeRequestHeaders(req)...)
 
-	bodyLogger := requestBodyLogger(ctx)
+	attributes = RedactionRuleForContext(ctx).RedactAttributes(attributes)
+
+	bodyLogger := RequestBodyLoggerFor(ctx)
 	err := bodyLogger.Log(ctx, req, &attributes)
 	if err != nil {
 		return nil, err
@@ -101,77 +103,107 @@ type ResponseBodyLogger interface {
 	Log(ctx context.Context, resp *http.Response, attrs *[]attribute.KeyValue) error
 }
 
-func requestBodyLogger(ctx context.Context) RequestBodyLogger {
-	if awsmiddleware.GetServiceID(ctx) == "S3" {
-		if op := awsmiddleware.GetOperationName(ctx); op == "PutObject" || op == "UploadPart" {
-			return &s3ObjectRequestBodyLogger{}
-		}
-	}
-
-	return &defaultRequestBodyLogger{}
-}
-
 var _ RequestBodyLogger = &defaultRequestBodyLogger{}
 
 type defaultRequestBodyLogger struct{}
 
 func (l *defaultRequestBodyLogger) Log(ctx context.Context, req *http.Request, attrs *[]attribute.KeyValue) error {
-	reqBytes, err := httputil.DumpRequestOut(req, true)
+	limit := RequestBodyLogLimit(ctx)
+
+	var content []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		content, req.Body, err = peekBody(req.Body, limit+bodyPeekPadding)
+		if err != nil {
+			return err
+		}
+	}
+
+	body, err := truncatedBody(ctx, content, req.Header.Get("Content-Type"), limit)
 	if err != nil {
 		return err
 	}
 
-	reader := textproto.NewReader(bufio.NewReader(bytes.NewReader(reqBytes)))
+	*attrs = append(*attrs, attribute.String("http.request.body", body))
 
-	if _, err = reader.ReadLine(); err != nil {
-		return err
-	}
+	return nil
+}
 
-	if _, err = reader.ReadMIMEHeader(); err != nil {
-		return err
+var _ ResponseBodyLogger = &defaultResponseBodyLogger{}
+
+type defaultResponseBodyLogger struct{}
+
+func (l *defaultResponseBodyLogger) Log(ctx context.Context, resp *http.Response, attrs *[]attribute.KeyValue) error {
+	limit := ResponseBodyLogLimit(ctx)
+
+	var content []byte
+	if resp.Body != nil && resp.Body != http.NoBody {
+		var err error
+		content, resp.Body, err = peekBody(resp.Body, limit+bodyPeekPadding)
+		if err != nil {
+			return err
+		}
 	}
 
-	body, err := ReadTruncatedBody(reader, maxRequestBodyLen)
+	body, err := truncatedBody(ctx, content, resp.Header.Get("Content-Type"), limit)
 	if err != nil {
 		return err
 	}
 
-	*attrs = append(*attrs, attribute.String("http.request.body", body))
+	*attrs = append(*attrs, attribute.String("http.response.body", body))
 
 	return nil
 }
 
-var _ RequestBodyLogger = &s3ObjectRequestBodyLogger{}
+// truncatedBody returns the body prefix to be logged, with sensitive values redacted.
+func truncatedBody(ctx context.Context, content []byte, contentType string, limit int) (string, error) {
+	content = RedactionRuleForContext(ctx).RedactBody(content, contentType)
+
+	reader := textproto.NewReader(bufio.NewReader(bytes.NewReader(content)))
+
+	return ReadTruncatedBody(reader, limit)
+}
 
-type s3ObjectRequestBodyLogger struct{}
+var _ RequestBodyLogger = &RedactedRequestBodyLogger{}
 
-func (l *s3ObjectRequestBodyLogger) Log(ctx context.Context, req *http.Request, attrs *[]attribute.KeyValue) error {
+// RedactedRequestBodyLogger logs only the length and Content-Type of request bodies.
+// It is used for operations with binary or sensitive payloads.
+type RedactedRequestBodyLogger struct{}
+
+func (l *RedactedRequestBodyLogger) Log(ctx context.Context, req *http.Request, attrs *[]attribute.KeyValue) error {
 	length := outgoingLength(req)
 	contentType := req.Header.Get("Content-Type")
 
-	body := s3BodyRedacted(length, contentType)
+	body := redactedBody(length, contentType)
 
 	*attrs = append(*attrs, attribute.String("http.request.body", body))
 
 	return nil
 }
 
-var _ ResponseBodyLogger = &S3ObjectResponseBodyLogger{}
+var _ ResponseBodyLogger = &RedactedResponseBodyLogger{}
+
+// RedactedResponseBodyLogger logs only the length and Content-Type of response bodies.
+// It is used for operations with binary or sensitive payloads.
+type RedactedResponseBodyLogger struct{}
 
-type S3ObjectResponseBodyLogger struct{}
+// S3ObjectResponseBodyLogger logs only the length and Content-Type of S3 object bodies.
+//
+// Deprecated: Use RedactedResponseBodyLogger.
+type S3ObjectResponseBodyLogger = RedactedResponseBodyLogger
 
-func (l *S3ObjectResponseBodyLogger) Log(ctx context.Context, resp *http.Response, attrs *[]attribute.KeyValue) error {
+func (l *RedactedResponseBodyLogger) Log(ctx context.Context, resp *http.Response, attrs *[]attribute.KeyValue) error {
 	length := resp.ContentLength
 	contentType := resp.Header.Get("Content-Type")
 
-	body := s3BodyRedacted(length, contentType)
+	body := redactedBody(length, contentType)
 
 	*attrs = append(*attrs, attribute.String("http.response.body", body))
 
 	return nil
 }
 
-func s3BodyRedacted(length int64, contentType string) string {
+func redactedBody(length int64, contentType string) string {
 	body := fmt.Sprintf("[Redacted: %s", formatByteSize(length))
 
 	if contentType != "" {
@@ -268,6 +300,16 @@ func authorizationHeaderAttribute(v string) (attribute.KeyValue, bool) {
 	}
 }
 
+// RequestResendCountAttribute returns the "http.resend_count" attribute for a request retried by the AWS SDK for Go v2,
+// read from the "Amz-Sdk-Request" header. ok is false if the request is the first attempt.
+func RequestResendCountAttribute(req *http.Request) (kv attribute.KeyValue, ok bool) {
+	attempt := req.Header.Values("Amz-Sdk-Request")
+	if len(attempt) == 0 {
+		return
+	}
+	return resendCountAttribute(attempt[0])
+}
+
 func resendCountAttribute(v string) (kv attribute.KeyValue, ok bool) {
 	re := regexp.MustCompile(`attempt=(\d+);`)
 	match := re.FindStringSubmatch(v)
@@ -280,6 +322,12 @@ func resendCountAttribute(v string) (kv attribute.KeyValue, ok bool) {
 		return
 	}
 
+	return ResendCountAttribute(attempt)
+}
+
+// ResendCountAttribute returns the "http.resend_count" attribute for the given attempt number of a request.
+// ok is false for the first attempt.
+func ResendCountAttribute(attempt int) (kv attribute.KeyValue, ok bool) {
 	if attempt > 1 {
 		return attribute.Int("http.resend_count", attempt), true
 	}
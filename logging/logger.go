@@ -17,3 +17,27 @@ type Logger interface {
 
 	SubLogger(ctx context.Context, name string) (context.Context, Logger)
 }
+
+// Level is the severity of a log message.
+type Level int
+
+const (
+	LevelTrace Level = iota
+	LevelDebug
+	LevelInfo
+	LevelWarn
+)
+
+// LevelEnabler is implemented by Loggers which can report whether messages of a given level are output.
+type LevelEnabler interface {
+	Enabled(ctx context.Context, level Level) bool
+}
+
+// Enabled returns whether the Logger outputs messages of the given level.
+// Loggers which do not implement LevelEnabler are assumed to output all levels.
+func Enabled(ctx context.Context, logger Logger, level Level) bool {
+	if l, ok := logger.(LevelEnabler); ok {
+		return l.Enabled(ctx, level)
+	}
+	return true
+}
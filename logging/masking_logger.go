@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package logging
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// MaskingLogger is a Logger which masks AWS sensitive values in messages and field values before passing them to the wrapped Logger.
+// Strings, errors, and the elements of slices, arrays, and maps are masked using MaskAWSSensitiveValues.
+type MaskingLogger struct {
+	logger Logger
+}
+
+var _ Logger = MaskingLogger{}
+var _ LevelEnabler = MaskingLogger{}
+
+// NewMaskingLogger returns a Logger which masks AWS sensitive values logged to the given Logger.
+// If the Logger already masks values, it is returned unchanged.
+func NewMaskingLogger(logger Logger) Logger {
+	if _, ok := logger.(MaskingLogger); ok {
+		return logger
+	}
+	return MaskingLogger{logger: logger}
+}
+
+func (l MaskingLogger) SubLogger(ctx context.Context, name string) (context.Context, Logger) {
+	ctx, logger := l.logger.SubLogger(ctx, name)
+
+	return ctx, NewMaskingLogger(logger)
+}
+
+func (l MaskingLogger) Warn(ctx context.Context, msg string, fields ...map[string]any) {
+	l.logger.Warn(ctx, MaskAWSSensitiveValues(msg), maskFields(fields)...)
+}
+
+func (l MaskingLogger) Info(ctx context.Context, msg string, fields ...map[string]any) {
+	l.logger.Info(ctx, MaskAWSSensitiveValues(msg), maskFields(fields)...)
+}
+
+func (l MaskingLogger) Debug(ctx context.Context, msg string, fields ...map[string]any) {
+	l.logger.Debug(ctx, MaskAWSSensitiveValues(msg), maskFields(fields)...)
+}
+
+func (l MaskingLogger) Trace(ctx context.Context, msg string, fields ...map[string]any) {
+	l.logger.Trace(ctx, MaskAWSSensitiveValues(msg), maskFields(fields)...)
+}
+
+func (l MaskingLogger) Enabled(ctx context.Context, level Level) bool {
+	return Enabled(ctx, l.logger, level)
+}
+
+func (l MaskingLogger) SetField(ctx context.Context, key string, value any) context.Context {
+	return l.logger.SetField(ctx, key, maskValue(value))
+}
+
+func maskFields(fields []map[string]any) []map[string]any {
+	result := make([]map[string]any, len(fields))
+	for i, m := range fields {
+		masked := make(map[string]any, len(m))
+		for k, v := range m {
+			masked[k] = maskValue(v)
+		}
+		result[i] = masked
+	}
+	return result
+}
+
+// maskValue returns the value with AWS sensitive values masked.
+// Errors are converted to their messages. Slices, arrays, and maps are masked recursively;
+// slices and arrays are returned as []any and maps as map[string]any.
+func maskValue(v any) any {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case string:
+		return MaskAWSSensitiveValues(v)
+	case []byte:
+		return []byte(MaskAWSSensitiveValues(string(v)))
+	case error:
+		return MaskAWSSensitiveValues(v.Error())
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr, float32, float64, complex64, complex128:
+		return v
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		return MaskAWSSensitiveValues(rv.String())
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return v
+		}
+		result := make([]any, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			result[i] = maskValue(rv.Index(i).Interface())
+		}
+		return result
+	case reflect.Map:
+		if rv.IsNil() {
+			return v
+		}
+		result := make(map[string]any, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			result[MaskAWSSensitiveValues(fmt.Sprint(iter.Key().Interface()))] = maskValue(iter.Value().Interface())
+		}
+		return result
+	}
+
+	return v
+}
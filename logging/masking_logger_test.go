@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package logging
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestMaskingLogger(t *testing.T) {
+	var buf bytes.Buffer
+	ctx, logger := hcLoggerFactory(context.Background(), "test", &buf)
+	logger = NewMaskingLogger(logger)
+
+	ctx = logger.SetField(ctx, "access_key", "AKIAI44QH8DHBEXAMPLE")
+	logger.Warn(ctx, "using access key AKIAI44QH8DHBEXAMPLE", map[string]any{
+		"error":  errors.New("invalid secret key wJalrXUtnFEMI/K7MDENG/bPxRfiCYEXAMPLEKEY"),
+		"ids":    []string{"AROA1234567890ABCDEFG", "not-an-id"},
+		"nested": map[string]any{"key": []any{"ASIAI44QH8DHBEXAMPLE", 1}},
+		"number": 1,
+	})
+
+	expected := []map[string]any{
+		{
+			"@level":     "warn",
+			"@module":    hclogRootName + ".test",
+			"@message":   "using access key AKIA************MPLE",
+			"access_key": "AKIA************MPLE",
+			"error":      "invalid secret key wJal********************************EKEY",
+			"ids":        []any{"AROA*************DEFG", "not-an-id"},
+			"nested":     map[string]any{"key": []any{"ASIA************MPLE", float64(1)}},
+			"number":     float64(1),
+		},
+	}
+
+	lines, err := tflogtest.MultilineJSONDecode(&buf)
+	if err != nil {
+		t.Fatalf("decoding log lines: %s", err)
+	}
+
+	if diff := cmp.Diff(expected, lines); diff != "" {
+		t.Errorf("unexpected logger output difference: %s", diff)
+	}
+}
+
+func TestMaskingLoggerSubLogger(t *testing.T) {
+	var buf bytes.Buffer
+	ctx, logger := NewHcLogger(context.Background(), configureHcLogger(&buf))
+
+	ctx, subLogger := NewMaskingLogger(logger).SubLogger(ctx, "sub")
+	if _, ok := subLogger.(MaskingLogger); !ok {
+		t.Fatalf("expected MaskingLogger, got %T", subLogger)
+	}
+
+	subLogger.Info(ctx, "AKIAI44QH8DHBEXAMPLE")
+
+	lines, err := tflogtest.MultilineJSONDecode(&buf)
+	if err != nil {
+		t.Fatalf("decoding log lines: %s", err)
+	}
+
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 log line, got %d", len(lines))
+	}
+	if a, e := lines[0]["@message"], "AKIA************MPLE"; a != e {
+		t.Errorf("expected message %q, got %q", e, a)
+	}
+}
+
+func TestNewMaskingLoggerIdempotent(t *testing.T) {
+	logger := NewMaskingLogger(NullLogger{})
+
+	if diff := cmp.Diff(logger, NewMaskingLogger(logger), cmp.AllowUnexported(MaskingLogger{})); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
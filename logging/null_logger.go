@@ -11,6 +11,7 @@ type NullLogger struct {
 }
 
 var _ Logger = NullLogger{}
+var _ LevelEnabler = NullLogger{}
 
 func (l NullLogger) SubLogger(ctx context.Context, name string) (context.Context, Logger) {
 	return ctx, l
@@ -28,6 +29,10 @@ func (l NullLogger) Debug(ctx context.Context, msg string, fields ...map[string]
 func (l NullLogger) Trace(ctx context.Context, msg string, fields ...map[string]any) {
 }
 
+func (l NullLogger) Enabled(ctx context.Context, level Level) bool {
+	return false
+}
+
 func (l NullLogger) SetField(ctx context.Context, key string, value any) context.Context {
 	return ctx
 }
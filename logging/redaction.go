@@ -0,0 +1,302 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime"
+	"regexp"
+	"strings"
+	"sync"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+const redactedValue = "*****"
+
+// RedactionRule describes sensitive values to mask when logging the HTTP requests and responses of an operation.
+type RedactionRule struct {
+	// JSONPaths are dot-separated paths to values in JSON bodies, e.g. "Parameter.Value".
+	// A path element of "*" matches every element of an array or every member of an object.
+	JSONPaths []string
+
+	// XMLElements are the names of elements in XML bodies whose content is masked.
+	XMLElements []string
+
+	// QueryParameters are the names of parameters in the request URL and in form-encoded bodies.
+	QueryParameters []string
+
+	// Headers are the names of request and response headers.
+	Headers []string
+}
+
+// IsEmpty returns whether the rule masks no values.
+func (r RedactionRule) IsEmpty() bool {
+	return len(r.JSONPaths) == 0 && len(r.XMLElements) == 0 && len(r.QueryParameters) == 0 && len(r.Headers) == 0
+}
+
+func (r RedactionRule) merge(other RedactionRule) RedactionRule {
+	return RedactionRule{
+		JSONPaths:       append(r.JSONPaths[:len(r.JSONPaths):len(r.JSONPaths)], other.JSONPaths...),
+		XMLElements:     append(r.XMLElements[:len(r.XMLElements):len(r.XMLElements)], other.XMLElements...),
+		QueryParameters: append(r.QueryParameters[:len(r.QueryParameters):len(r.QueryParameters)], other.QueryParameters...),
+		Headers:         append(r.Headers[:len(r.Headers):len(r.Headers)], other.Headers...),
+	}
+}
+
+// RedactBody masks the sensitive values in an HTTP body with the given Content-Type.
+// If the body cannot be parsed, e.g. because it has been truncated, the entire body is redacted.
+func (r RedactionRule) RedactBody(body []byte, contentType string) []byte {
+	if r.IsEmpty() || len(body) == 0 {
+		return body
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case strings.Contains(mediaType, "json"):
+		if len(r.JSONPaths) == 0 {
+			return body
+		}
+		redacted, err := redactJSON(body, r.JSONPaths)
+		if err != nil {
+			return []byte(redactedBody(int64(len(body)), contentType))
+		}
+		return redacted
+
+	case strings.Contains(mediaType, "xml"):
+		for _, name := range r.XMLElements {
+			body = xmlElementRegex(name).ReplaceAll(body, []byte("${1}"+redactedValue+"${2}"))
+		}
+		return body
+
+	case mediaType == "application/x-www-form-urlencoded":
+		for _, name := range r.QueryParameters {
+			body = queryParameterRegex(name).ReplaceAll(body, []byte("${1}"+redactedValue))
+		}
+		return body
+	}
+
+	return body
+}
+
+// RedactAttributes masks the sensitive values in HTTP header and URL attributes.
+func (r RedactionRule) RedactAttributes(attrs []attribute.KeyValue) []attribute.KeyValue {
+	if r.IsEmpty() {
+		return attrs
+	}
+
+	headers := make(map[attribute.Key]bool, 2*len(r.Headers)) //nolint:mnd
+	for _, name := range r.Headers {
+		headers[RequestHeaderAttributeKey(name)] = true
+		headers[ResponseHeaderAttributeKey(name)] = true
+	}
+
+	result := make([]attribute.KeyValue, len(attrs))
+	for i, attr := range attrs {
+		switch {
+		case headers[attr.Key]:
+			attr = attr.Key.String(redactedValue)
+
+		case attr.Key == semconv.HTTPURLKey:
+			v := attr.Value.AsString()
+			for _, name := range r.QueryParameters {
+				v = queryParameterRegex(name).ReplaceAllString(v, "${1}"+redactedValue)
+			}
+			attr = attr.Key.String(v)
+		}
+		result[i] = attr
+	}
+
+	return result
+}
+
+func redactJSON(body []byte, paths []string) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var v any
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	for _, path := range paths {
+		v = redactJSONPath(v, strings.Split(path, "."))
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+func redactJSONPath(v any, path []string) any {
+	if len(path) == 0 {
+		return redactedValue
+	}
+
+	switch v := v.(type) {
+	case map[string]any:
+		for k, e := range v {
+			if path[0] == "*" || path[0] == k {
+				v[k] = redactJSONPath(e, path[1:])
+			}
+		}
+
+	case []any:
+		if path[0] == "*" {
+			for i, e := range v {
+				v[i] = redactJSONPath(e, path[1:])
+			}
+		}
+	}
+
+	return v
+}
+
+// xmlElementRegex matches the element with the given local name, capturing the start and end tags.
+// An element which is not closed, e.g. because the body has been truncated, is matched to the end of the body.
+func xmlElementRegex(name string) *regexp.Regexp {
+	name = regexp.QuoteMeta(name)
+	return redactionRegex(`(<(?:[\w.-]+:)?` + name + `(?:\s[^>]*)?>)[^<]*(</(?:[\w.-]+:)?` + name + `>|\z)`)
+}
+
+// queryParameterRegex matches the parameter with the given name, capturing everything up to the value.
+func queryParameterRegex(name string) *regexp.Regexp {
+	return redactionRegex(`((?:^|[?&])` + regexp.QuoteMeta(name) + `=)[^&#]*`)
+}
+
+// redactionRegexes caches compiled redaction regular expressions by expression.
+// The expressions are derived from the names in registered rules, so the cache is bounded.
+var redactionRegexes sync.Map
+
+func redactionRegex(expr string) *regexp.Regexp {
+	if re, ok := redactionRegexes.Load(expr); ok {
+		return re.(*regexp.Regexp) //nolint:forcetypeassert
+	}
+	re, _ := redactionRegexes.LoadOrStore(expr, regexp.MustCompile(expr))
+	return re.(*regexp.Regexp) //nolint:forcetypeassert
+}
+
+type operationKeyT string
+
+const operationKey operationKeyT = "operation-key"
+
+type operation struct {
+	serviceID string
+	name      string
+}
+
+// WithOperation returns a context identifying the AWS operation being logged.
+// It is only needed when the service ID and operation name are not available from AWS SDK for Go v2 middleware metadata.
+func WithOperation(ctx context.Context, serviceID, operationName string) context.Context {
+	return context.WithValue(ctx, operationKey, operation{
+		serviceID: serviceID,
+		name:      operationName,
+	})
+}
+
+func operationFromContext(ctx context.Context) (string, string) {
+	if op, ok := ctx.Value(operationKey).(operation); ok {
+		return op.serviceID, op.name
+	}
+	return awsmiddleware.GetServiceID(ctx), awsmiddleware.GetOperationName(ctx)
+}
+
+var redactionRules = struct {
+	mu    sync.RWMutex
+	rules map[operation]RedactionRule
+}{
+	rules: builtinRedactionRules(),
+}
+
+// RegisterRedactionRule registers a rule for the given service ID and operation, in addition to any rules already registered.
+// If operationName is empty, the rule applies to all operations of the service.
+func RegisterRedactionRule(serviceID, operationName string, rule RedactionRule) {
+	redactionRules.mu.Lock()
+	defer redactionRules.mu.Unlock()
+
+	key := operation{serviceID: serviceID, name: operationName}
+	redactionRules.rules[key] = redactionRules.rules[key].merge(rule)
+}
+
+// RedactionRuleFor returns the combined rules registered for the given service ID and operation.
+func RedactionRuleFor(serviceID, operationName string) RedactionRule {
+	redactionRules.mu.RLock()
+	defer redactionRules.mu.RUnlock()
+
+	rule := redactionRules.rules[operation{serviceID: serviceID}]
+	if operationName != "" {
+		rule = rule.merge(redactionRules.rules[operation{serviceID: serviceID, name: operationName}])
+	}
+	return rule
+}
+
+// RedactionRuleForContext returns the combined rules registered for the AWS operation in the context.
+func RedactionRuleForContext(ctx context.Context) RedactionRule {
+	return RedactionRuleFor(operationFromContext(ctx))
+}
+
+func builtinRedactionRules() map[operation]RedactionRule {
+	rules := make(map[operation]RedactionRule)
+	add := func(serviceID string, operationNames []string, rule RedactionRule) {
+		for _, name := range operationNames {
+			key := operation{serviceID: serviceID, name: name}
+			rules[key] = rules[key].merge(rule)
+		}
+	}
+
+	add("KMS", []string{"Decrypt", "Encrypt", "GenerateDataKey", "GenerateRandom"}, RedactionRule{
+		JSONPaths: []string{"Plaintext"},
+	})
+	add("KMS", []string{"GenerateDataKeyPair"}, RedactionRule{
+		JSONPaths: []string{"PrivateKeyPlaintext"},
+	})
+
+	add("Secrets Manager", []string{"CreateSecret", "GetSecretValue", "PutSecretValue", "UpdateSecret"}, RedactionRule{
+		JSONPaths: []string{"SecretBinary", "SecretString"},
+	})
+	add("Secrets Manager", []string{"BatchGetSecretValue"}, RedactionRule{
+		JSONPaths: []string{"SecretValues.*.SecretBinary", "SecretValues.*.SecretString"},
+	})
+	add("Secrets Manager", []string{"GetRandomPassword"}, RedactionRule{
+		JSONPaths: []string{"RandomPassword"},
+	})
+
+	// Parameter values are masked regardless of type, as the type of a parameter is not included in all requests.
+	add("SSM", []string{"PutParameter"}, RedactionRule{
+		JSONPaths: []string{"Value"},
+	})
+	add("SSM", []string{"GetParameter"}, RedactionRule{
+		JSONPaths: []string{"Parameter.Value"},
+	})
+	add("SSM", []string{"GetParameterHistory", "GetParameters", "GetParametersByPath"}, RedactionRule{
+		JSONPaths: []string{"Parameters.*.Value"},
+	})
+
+	add("SSO", []string{"GetRoleCredentials"}, RedactionRule{
+		JSONPaths: []string{"roleCredentials.secretAccessKey", "roleCredentials.sessionToken"},
+		Headers:   []string{"X-Amz-Sso_bearer_token"},
+	})
+
+	stsCredentials := RedactionRule{
+		XMLElements: []string{"SecretAccessKey", "SessionToken"},
+	}
+	add("STS", []string{"AssumeRole", "AssumeRoleWithSAML", "AssumeRoleWithWebIdentity", "GetFederationToken", "GetSessionToken"}, stsCredentials)
+	add("STS", []string{"AssumeRoleWithSAML"}, RedactionRule{
+		QueryParameters: []string{"SAMLAssertion"},
+	})
+	add("STS", []string{"AssumeRoleWithWebIdentity"}, RedactionRule{
+		QueryParameters: []string{"WebIdentityToken"},
+	})
+
+	return rules
+}
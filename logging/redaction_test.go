@@ -0,0 +1,346 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package logging
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/aws-sdk-go-base/v2/servicemocks"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestRedactBody(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]struct {
+		rule        RedactionRule
+		body        string
+		contentType string
+		expected    string
+	}{
+		"empty rule": {
+			body:        `{"SecretString":"secret"}`,
+			contentType: "application/x-amz-json-1.1",
+			expected:    `{"SecretString":"secret"}`,
+		},
+
+		"JSON": {
+			rule: RedactionRule{
+				JSONPaths: []string{"SecretString"},
+			},
+			body:        `{"Name": "test", "SecretString": "secret", "VersionId": 1}`,
+			contentType: "application/x-amz-json-1.1",
+			expected:    `{"Name":"test","SecretString":"*****","VersionId":1}`,
+		},
+
+		"JSON nested": {
+			rule: RedactionRule{
+				JSONPaths: []string{"Parameter.Value"},
+			},
+			body:        `{"Parameter":{"Name":"test","Value":"secret"},"Value":"not-secret"}`,
+			contentType: "application/x-amz-json-1.1",
+			expected:    `{"Parameter":{"Name":"test","Value":"*****"},"Value":"not-secret"}`,
+		},
+
+		"JSON wildcard": {
+			rule: RedactionRule{
+				JSONPaths: []string{"Parameters.*.Value"},
+			},
+			body:        `{"Parameters":[{"Name":"one","Value":"secret1"},{"Name":"two","Value":"secret2"}]}`,
+			contentType: "application/x-amz-json-1.1",
+			expected:    `{"Parameters":[{"Name":"one","Value":"*****"},{"Name":"two","Value":"*****"}]}`,
+		},
+
+		"JSON missing path": {
+			rule: RedactionRule{
+				JSONPaths: []string{"SecretString"},
+			},
+			body:        `{"Name":"test"}`,
+			contentType: "application/x-amz-json-1.1",
+			expected:    `{"Name":"test"}`,
+		},
+
+		"JSON truncated": {
+			rule: RedactionRule{
+				JSONPaths: []string{"SecretString"},
+			},
+			body:        `{"Name":"test","SecretString":"sec`,
+			contentType: "application/x-amz-json-1.1",
+			expected:    `[Redacted: 34 bytes, Type: application/x-amz-json-1.1]`,
+		},
+
+		"XML": {
+			rule: RedactionRule{
+				XMLElements: []string{"SecretAccessKey", "SessionToken"},
+			},
+			body: `<Credentials>
+  <AccessKeyId>ASIAEXAMPLE</AccessKeyId>
+  <SecretAccessKey>secret</SecretAccessKey>
+  <SessionToken>token</SessionToken>
+</Credentials>`,
+			contentType: "text/xml",
+			expected: `<Credentials>
+  <AccessKeyId>ASIAEXAMPLE</AccessKeyId>
+  <SecretAccessKey>*****</SecretAccessKey>
+  <SessionToken>*****</SessionToken>
+</Credentials>`,
+		},
+
+		"XML namespace prefix": {
+			rule: RedactionRule{
+				XMLElements: []string{"SessionToken"},
+			},
+			body:        `<sts:SessionToken xmlns:sts="https://sts.amazonaws.com/doc/2011-06-15/">token</sts:SessionToken>`,
+			contentType: "application/xml; charset=utf-8",
+			expected:    `<sts:SessionToken xmlns:sts="https://sts.amazonaws.com/doc/2011-06-15/">*****</sts:SessionToken>`,
+		},
+
+		"form": {
+			rule: RedactionRule{
+				QueryParameters: []string{"WebIdentityToken"},
+			},
+			body:        `Action=AssumeRoleWithWebIdentity&RoleArn=arn%3Aaws%3Aiam%3A%3A123456789012%3Arole%2Ftest&WebIdentityToken=token&Version=2011-06-15`,
+			contentType: "application/x-www-form-urlencoded; charset=utf-8",
+			expected:    `Action=AssumeRoleWithWebIdentity&RoleArn=arn%3Aaws%3Aiam%3A%3A123456789012%3Arole%2Ftest&WebIdentityToken=*****&Version=2011-06-15`,
+		},
+
+		"unknown content type": {
+			rule: RedactionRule{
+				JSONPaths: []string{"SecretString"},
+			},
+			body:        `{"SecretString":"secret"}`,
+			contentType: "application/octet-stream",
+			expected:    `{"SecretString":"secret"}`,
+		},
+	}
+
+	for name, testcase := range testcases {
+		testcase := testcase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			actual := string(testcase.rule.RedactBody([]byte(testcase.body), testcase.contentType))
+
+			if diff := cmp.Diff(testcase.expected, actual); diff != "" {
+				t.Errorf("unexpected diff (+wanted, -got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestRedactBodyCredentials(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]struct {
+		serviceID   string
+		operation   string
+		body        string
+		contentType string
+		redacted    []string
+		unredacted  []string
+	}{
+		"STS AssumeRole": {
+			serviceID:   "STS",
+			operation:   "AssumeRole",
+			body:        servicemocks.MockStsAssumeRoleValidResponseBody,
+			contentType: "text/xml",
+			redacted: []string{
+				servicemocks.MockStsAssumeRoleSecretKey,
+				servicemocks.MockStsAssumeRoleSessionToken,
+			},
+			unredacted: []string{
+				servicemocks.MockStsAssumeRoleAccessKey,
+				"2099-12-31T23:59:59Z",
+			},
+		},
+
+		"STS AssumeRole truncated": {
+			serviceID:   "STS",
+			operation:   "AssumeRole",
+			body:        servicemocks.MockStsAssumeRoleValidResponseBody[:strings.Index(servicemocks.MockStsAssumeRoleValidResponseBody, servicemocks.MockStsAssumeRoleSessionToken)+10],
+			contentType: "text/xml",
+			redacted: []string{
+				servicemocks.MockStsAssumeRoleSecretKey,
+				"<SessionToken>" + servicemocks.MockStsAssumeRoleSessionToken[:10],
+			},
+		},
+
+		"STS AssumeRoleWithWebIdentity": {
+			serviceID:   "STS",
+			operation:   "AssumeRoleWithWebIdentity",
+			body:        servicemocks.MockStsAssumeRoleWithWebIdentityValidResponseBody,
+			contentType: "text/xml",
+			redacted: []string{
+				servicemocks.MockStsAssumeRoleWithWebIdentitySecretKey,
+				servicemocks.MockStsAssumeRoleWithWebIdentitySessionToken,
+			},
+			unredacted: []string{
+				servicemocks.MockStsAssumeRoleWithWebIdentityAccessKey,
+			},
+		},
+
+		"STS AssumeRoleWithWebIdentity request": {
+			serviceID: "STS",
+			operation: "AssumeRoleWithWebIdentity",
+			body: url.Values{
+				"Action":           []string{"AssumeRoleWithWebIdentity"},
+				"RoleArn":          []string{servicemocks.MockStsAssumeRoleWithWebIdentityArn},
+				"WebIdentityToken": []string{servicemocks.MockWebIdentityToken},
+			}.Encode(),
+			contentType: "application/x-www-form-urlencoded",
+			redacted: []string{
+				"WebIdentityToken=" + servicemocks.MockWebIdentityToken,
+			},
+			unredacted: []string{
+				url.QueryEscape(servicemocks.MockStsAssumeRoleWithWebIdentityArn),
+			},
+		},
+
+		"SSO GetRoleCredentials": {
+			serviceID: "SSO",
+			operation: "GetRoleCredentials",
+			body: fmt.Sprintf(`{"roleCredentials":{"accessKeyId":%q,"secretAccessKey":%q,"sessionToken":%q,"expiration":1700000000000}}`,
+				servicemocks.MockSsoAccessKeyID,
+				servicemocks.MockSsoSecretAccessKey,
+				servicemocks.MockSsoSessionToken,
+			),
+			contentType: "application/json",
+			redacted: []string{
+				servicemocks.MockSsoSecretAccessKey,
+				servicemocks.MockSsoSessionToken,
+			},
+			unredacted: []string{
+				servicemocks.MockSsoAccessKeyID,
+			},
+		},
+	}
+
+	for name, testcase := range testcases {
+		testcase := testcase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			rule := RedactionRuleFor(testcase.serviceID, testcase.operation)
+			actual := string(rule.RedactBody([]byte(testcase.body), testcase.contentType))
+
+			for _, v := range testcase.redacted {
+				if strings.Contains(actual, v) {
+					t.Errorf("expected %q to be redacted, got %s", v, actual)
+				}
+			}
+			for _, v := range testcase.unredacted {
+				if !strings.Contains(actual, v) {
+					t.Errorf("expected %q not to be redacted, got %s", v, actual)
+				}
+			}
+		})
+	}
+}
+
+func TestRedactAttributes(t *testing.T) {
+	t.Parallel()
+
+	rule := RedactionRule{
+		QueryParameters: []string{"token"},
+		Headers:         []string{"X-Amz-Sso_bearer_token"},
+	}
+
+	attrs := []attribute.KeyValue{
+		attribute.String("http.url", "https://example.com/path?name=test&token=secret"),
+		RequestHeaderAttributeKey("X-Amz-Sso_bearer_token").String("bearer"),
+		ResponseHeaderAttributeKey("X-Amz-Sso_bearer_token").String("bearer"),
+		RequestHeaderAttributeKey("Content-Type").String("application/json"),
+	}
+
+	expected := []attribute.KeyValue{
+		attribute.String("http.url", "https://example.com/path?name=test&token=*****"),
+		RequestHeaderAttributeKey("X-Amz-Sso_bearer_token").String("*****"),
+		ResponseHeaderAttributeKey("X-Amz-Sso_bearer_token").String("*****"),
+		RequestHeaderAttributeKey("Content-Type").String("application/json"),
+	}
+
+	if diff := cmp.Diff(expected, rule.RedactAttributes(attrs), cmp.Comparer(func(x, y attribute.Value) bool { return x == y })); diff != "" {
+		t.Errorf("unexpected diff (+wanted, -got): %s", diff)
+	}
+}
+
+func TestRedactionRegexCache(t *testing.T) {
+	t.Parallel()
+
+	if xmlElementRegex("SecretAccessKey") != xmlElementRegex("SecretAccessKey") {
+		t.Error("expected XML element regular expression to be compiled once")
+	}
+	if queryParameterRegex("token") != queryParameterRegex("token") {
+		t.Error("expected query parameter regular expression to be compiled once")
+	}
+	if xmlElementRegex("token") == queryParameterRegex("token") {
+		t.Error("expected different regular expressions for XML elements and query parameters")
+	}
+}
+
+func TestRedactionRuleFor(t *testing.T) {
+	t.Parallel()
+
+	if rule := RedactionRuleFor("Secrets Manager", "GetSecretValue"); rule.IsEmpty() {
+		t.Error("expected built-in rule for Secrets Manager GetSecretValue")
+	}
+	if rule := RedactionRuleFor("STS", "GetCallerIdentity"); !rule.IsEmpty() {
+		t.Errorf("expected no rule for STS GetCallerIdentity, got %+v", rule)
+	}
+
+	const serviceID = "TestRedactionRuleFor"
+	RegisterRedactionRule(serviceID, "", RedactionRule{
+		Headers: []string{"X-Service"},
+	})
+	RegisterRedactionRule(serviceID, "Operation", RedactionRule{
+		JSONPaths: []string{"One"},
+	})
+	RegisterRedactionRule(serviceID, "Operation", RedactionRule{
+		JSONPaths: []string{"Two"},
+	})
+
+	expected := RedactionRule{
+		JSONPaths: []string{"One", "Two"},
+		Headers:   []string{"X-Service"},
+	}
+	if diff := cmp.Diff(expected, RedactionRuleFor(serviceID, "Operation")); diff != "" {
+		t.Errorf("unexpected diff (+wanted, -got): %s", diff)
+	}
+
+	expected = RedactionRule{
+		Headers: []string{"X-Service"},
+	}
+	if diff := cmp.Diff(expected, RedactionRuleFor(serviceID, "OtherOperation")); diff != "" {
+		t.Errorf("unexpected diff (+wanted, -got): %s", diff)
+	}
+}
+
+func TestDecomposeHTTPRequestRedaction(t *testing.T) {
+	t.Parallel()
+
+	ctx := WithOperation(context.Background(), "Secrets Manager", "PutSecretValue")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://secretsmanager.us-east-1.amazonaws.com/", strings.NewReader(`{"SecretId":"test","SecretString":"secret"}`))
+	if err != nil {
+		t.Fatalf("creating request: %s", err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+
+	fields, err := DecomposeHTTPRequest(ctx, req)
+	if err != nil {
+		t.Fatalf("decomposing request: %s", err)
+	}
+
+	if a, e := fields["http.request.body"], `{"SecretId":"test","SecretString":"*****"}`+"\n"; a != e {
+		t.Errorf("expected body %q, got %q", e, a)
+	}
+}
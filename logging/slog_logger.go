@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+)
+
+// SlogLevelTrace is the log/slog level used for Trace messages, below slog.LevelDebug.
+const SlogLevelTrace = slog.Level(-8)
+
+// slogMaskingReplacement is the replacement for masked field values, matching terraform-plugin-log.
+const slogMaskingReplacement = "***"
+
+type slogLoggerKeyT string
+
+const slogLoggerKey slogLoggerKeyT = "slog-logger-key"
+
+// SlogLogger is a Logger which writes to the *slog.Logger carried in the context.
+// Subloggers write their fields in a group named for the subsystem.
+// As with the masking configured for terraform-plugin-log, AWS unique IDs in string field values are masked.
+type SlogLogger struct{}
+
+var _ Logger = SlogLogger{}
+var _ LevelEnabler = SlogLogger{}
+
+func NewSlogLogger(ctx context.Context, logger *slog.Logger) (context.Context, SlogLogger) {
+	ctx = context.WithValue(ctx, slogLoggerKey, logger)
+
+	return ctx, SlogLogger{}
+}
+
+func slogFromContext(ctx context.Context) *slog.Logger {
+	logger, ok := ctx.Value(slogLoggerKey).(*slog.Logger)
+	if !ok {
+		return slog.Default()
+	}
+	return logger
+}
+
+func (l SlogLogger) SubLogger(ctx context.Context, name string) (context.Context, Logger) {
+	logger := slogFromContext(ctx)
+	logger = logger.WithGroup(name)
+	ctx = context.WithValue(ctx, slogLoggerKey, logger)
+
+	return ctx, SlogLogger{}
+}
+
+func (l SlogLogger) Warn(ctx context.Context, msg string, fields ...map[string]any) {
+	l.log(ctx, slog.LevelWarn, msg, fields...)
+}
+
+func (l SlogLogger) Info(ctx context.Context, msg string, fields ...map[string]any) {
+	l.log(ctx, slog.LevelInfo, msg, fields...)
+}
+
+func (l SlogLogger) Debug(ctx context.Context, msg string, fields ...map[string]any) {
+	l.log(ctx, slog.LevelDebug, msg, fields...)
+}
+
+func (l SlogLogger) Trace(ctx context.Context, msg string, fields ...map[string]any) {
+	l.log(ctx, SlogLevelTrace, msg, fields...)
+}
+
+func (l SlogLogger) Enabled(ctx context.Context, level Level) bool {
+	return slogFromContext(ctx).Enabled(ctx, slogLevel(level))
+}
+
+func slogLevel(level Level) slog.Level {
+	switch level {
+	case LevelTrace:
+		return SlogLevelTrace
+	case LevelDebug:
+		return slog.LevelDebug
+	case LevelInfo:
+		return slog.LevelInfo
+	default:
+		return slog.LevelWarn
+	}
+}
+
+func (l SlogLogger) log(ctx context.Context, level slog.Level, msg string, fields ...map[string]any) {
+	logger := slogFromContext(ctx)
+	if !logger.Enabled(ctx, level) {
+		return
+	}
+	logger.LogAttrs(ctx, level, msg, slogAttrs(fields...)...)
+}
+
+func (l SlogLogger) SetField(ctx context.Context, key string, value any) context.Context {
+	logger := slogFromContext(ctx)
+	logger = logger.With(slogAttr(key, value))
+	ctx = context.WithValue(ctx, slogLoggerKey, logger)
+	return ctx
+}
+
+// slogAttrs returns the fields as attributes, sorted by key. Later fields replace earlier fields with the same key.
+func slogAttrs(fields ...map[string]any) []slog.Attr {
+	merged := make(map[string]any)
+	for _, m := range fields {
+		for k, v := range m {
+			merged[k] = v
+		}
+	}
+
+	keys := make([]string, 0, len(merged))
+	for k := range merged {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	attrs := make([]slog.Attr, 0, len(keys))
+	for _, k := range keys {
+		attrs = append(attrs, slogAttr(k, merged[k]))
+	}
+	return attrs
+}
+
+func slogAttr(key string, value any) slog.Attr {
+	if s, ok := value.(string); ok {
+		value = UniqueIDRegex.ReplaceAllString(s, slogMaskingReplacement)
+	}
+	return slog.Any(key, value)
+}
+
+// SlogReplaceAttr can be used as the ReplaceAttr option of a slog.Handler to output the name "TRACE" for SlogLevelTrace.
+func SlogReplaceAttr(groups []string, a slog.Attr) slog.Attr {
+	if a.Key == slog.LevelKey && len(groups) == 0 {
+		if level, ok := a.Value.Any().(slog.Level); ok && level == SlogLevelTrace {
+			a.Value = slog.StringValue("TRACE")
+		}
+	}
+	return a
+}
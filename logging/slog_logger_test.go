@@ -0,0 +1,203 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package logging
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestSlogLoggerWarn(t *testing.T) {
+	var buf bytes.Buffer
+	ctx, logger := slogLoggerFactory(context.Background(), "test", &buf)
+
+	logger.Warn(ctx, "message", map[string]any{
+		"one": int(1),
+		"two": "two",
+	})
+
+	expected := []map[string]any{
+		{
+			"level": "WARN",
+			"msg":   "message",
+			"test": map[string]any{
+				"one": float64(1),
+				"two": "two",
+			},
+		},
+	}
+
+	if diff := cmp.Diff(expected, decodeSlogLines(t, &buf)); diff != "" {
+		t.Errorf("unexpected logger output difference: %s", diff)
+	}
+}
+
+func TestSlogLoggerTrace(t *testing.T) {
+	var buf bytes.Buffer
+	ctx, logger := slogLoggerFactory(context.Background(), "test", &buf)
+
+	logger.Trace(ctx, "message")
+
+	expected := []map[string]any{
+		{
+			"level": "TRACE",
+			"msg":   "message",
+		},
+	}
+
+	if diff := cmp.Diff(expected, decodeSlogLines(t, &buf)); diff != "" {
+		t.Errorf("unexpected logger output difference: %s", diff)
+	}
+}
+
+func TestSlogLoggerLevel(t *testing.T) {
+	var buf bytes.Buffer
+	ctx, logger := NewSlogLogger(context.Background(), slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		Level: slog.LevelInfo,
+	})))
+
+	logger.Trace(ctx, "trace")
+	logger.Debug(ctx, "debug")
+
+	if buf.Len() != 0 {
+		t.Errorf("expected no output, got %q", buf.String())
+	}
+}
+
+func TestSlogLoggerEnabled(t *testing.T) {
+	var buf bytes.Buffer
+	ctx, logger := NewSlogLogger(context.Background(), slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		Level: slog.LevelInfo,
+	})))
+
+	for level, expected := range map[Level]bool{
+		LevelTrace: false,
+		LevelDebug: false,
+		LevelInfo:  true,
+		LevelWarn:  true,
+	} {
+		if a := Enabled(ctx, logger, level); a != expected {
+			t.Errorf("level %d: expected %t, got %t", level, expected, a)
+		}
+		if a := Enabled(ctx, NewMaskingLogger(logger), level); a != expected {
+			t.Errorf("masking, level %d: expected %t, got %t", level, expected, a)
+		}
+	}
+}
+
+func TestSlogLoggerSetField(t *testing.T) {
+	var buf bytes.Buffer
+	originalCtx, logger := slogLoggerFactory(context.Background(), "test", &buf)
+
+	newCtx := logger.SetField(originalCtx, "key", "value")
+
+	logger.Warn(newCtx, "new logger")
+	logger.Warn(originalCtx, "original logger")
+
+	expected := []map[string]any{
+		{
+			"level": "WARN",
+			"msg":   "new logger",
+			"test": map[string]any{
+				"key": "value",
+			},
+		},
+		{
+			"level": "WARN",
+			"msg":   "original logger",
+		},
+	}
+
+	if diff := cmp.Diff(expected, decodeSlogLines(t, &buf)); diff != "" {
+		t.Errorf("unexpected logger output difference: %s", diff)
+	}
+}
+
+func TestSlogLoggerNestedSubLogger(t *testing.T) {
+	var buf bytes.Buffer
+	ctx, logger := slogLoggerFactory(context.Background(), "outer", &buf)
+	ctx, logger = logger.SubLogger(ctx, "inner")
+
+	logger.Info(ctx, "message", map[string]any{
+		"key": "value",
+	})
+
+	expected := []map[string]any{
+		{
+			"level": "INFO",
+			"msg":   "message",
+			"outer": map[string]any{
+				"inner": map[string]any{
+					"key": "value",
+				},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(expected, decodeSlogLines(t, &buf)); diff != "" {
+		t.Errorf("unexpected logger output difference: %s", diff)
+	}
+}
+
+func TestSlogLoggerMasking(t *testing.T) {
+	var buf bytes.Buffer
+	ctx, logger := slogLoggerFactory(context.Background(), "test", &buf)
+
+	ctx = logger.SetField(ctx, "access_key", "AKIAI44QH8DHBEXAMPLE")
+	logger.Info(ctx, "message", map[string]any{
+		"arn":    "arn:aws:iam::123456789012:role/AROA1234567890ABCDEFG",
+		"number": 1,
+	})
+
+	expected := []map[string]any{
+		{
+			"level": "INFO",
+			"msg":   "message",
+			"test": map[string]any{
+				"access_key": "***",
+				"arn":        "arn:aws:iam::123456789012:role/***",
+				"number":     float64(1),
+			},
+		},
+	}
+
+	if diff := cmp.Diff(expected, decodeSlogLines(t, &buf)); diff != "" {
+		t.Errorf("unexpected logger output difference: %s", diff)
+	}
+}
+
+func slogLoggerFactory(ctx context.Context, name string, output io.Writer) (context.Context, Logger) {
+	slogger := slog.New(slog.NewJSONHandler(output, &slog.HandlerOptions{
+		Level: SlogLevelTrace,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			// Omit the timestamp for comparison.
+			if a.Key == slog.TimeKey && len(groups) == 0 {
+				return slog.Attr{}
+			}
+			return SlogReplaceAttr(groups, a)
+		},
+	}))
+
+	ctx, rootLogger := NewSlogLogger(ctx, slogger)
+	ctx, logger := rootLogger.SubLogger(ctx, name)
+
+	return ctx, logger
+}
+
+func decodeSlogLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	lines, err := tflogtest.MultilineJSONDecode(buf)
+	if err != nil {
+		t.Fatalf("decoding log lines: %s", err)
+	}
+
+	return lines
+}
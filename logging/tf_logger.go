@@ -5,13 +5,18 @@ package logging
 
 import (
 	"context"
+	"os"
+	"strings"
+	"testing"
 
+	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 type TfLogger string
 
 var _ Logger = TfLogger("")
+var _ LevelEnabler = TfLogger("")
 
 func NewTfLogger(ctx context.Context) (context.Context, TfLogger) {
 	return ctx, TfLogger("")
@@ -56,6 +61,54 @@ func (l TfLogger) Trace(ctx context.Context, msg string, fields ...map[string]an
 	}
 }
 
+// Enabled reports whether messages of the given level are output when logging using terraform-plugin-log.
+// Terraform only outputs a provider's messages at or above the level set by the TF_LOG_PROVIDER environment variable or,
+// if it is not set, the TF_LOG environment variable. A logger's own level may still discard messages for which Enabled returns true.
+// In test binaries messages are always assumed to be output, since loggers created by tflogtest or the test sink
+// don't filter messages using the same environment variables.
+func (l TfLogger) Enabled(_ context.Context, level Level) bool {
+	if testing.Testing() {
+		return true
+	}
+
+	return hclogLevel(level) >= tfLogLevel()
+}
+
+// tfLogLevel returns the level of provider messages output by Terraform.
+func tfLogLevel() hclog.Level {
+	v := os.Getenv("TF_LOG_PROVIDER")
+	if v == "" {
+		v = os.Getenv("TF_LOG")
+	}
+
+	switch v = strings.ToUpper(v); v {
+	case "":
+		return hclog.Off
+	case "JSON":
+		return hclog.Trace
+	}
+
+	// Terraform outputs all messages if the level is invalid.
+	if level := hclog.LevelFromString(v); level != hclog.NoLevel {
+		return level
+	}
+
+	return hclog.Trace
+}
+
+func hclogLevel(level Level) hclog.Level {
+	switch level {
+	case LevelTrace:
+		return hclog.Trace
+	case LevelDebug:
+		return hclog.Debug
+	case LevelInfo:
+		return hclog.Info
+	default:
+		return hclog.Warn
+	}
+}
+
 func (l TfLogger) SetField(ctx context.Context, key string, value any) context.Context {
 	if l == "" {
 		return tflog.SetField(ctx, key, value)
@@ -8,6 +8,7 @@ import (
 	"io"
 	"testing"
 
+	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/terraform-plugin-log/tflogtest"
 )
 
@@ -21,6 +22,72 @@ func TestTfLoggerSetField(t *testing.T) {
 	testLoggerSetField(t, tflogRootName, tfLoggerFactory)
 }
 
+func TestTfLoggerEnabled(t *testing.T) {
+	t.Setenv("TF_LOG", "")
+	t.Setenv("TF_LOG_PROVIDER", "")
+
+	// Loggers in tests output messages regardless of the environment.
+	ctx, logger := NewTfLogger(tflogtest.RootLogger(context.Background(), io.Discard))
+
+	for _, level := range []Level{LevelTrace, LevelDebug, LevelInfo, LevelWarn} {
+		if !Enabled(ctx, logger, level) {
+			t.Errorf("level %d: expected enabled", level)
+		}
+	}
+}
+
+func TestTfLogLevel(t *testing.T) {
+	testcases := map[string]struct {
+		tfLog         string
+		tfLogProvider string
+		expected      hclog.Level
+	}{
+		"not set": {
+			expected: hclog.Off,
+		},
+		"TF_LOG": {
+			tfLog:    "INFO",
+			expected: hclog.Info,
+		},
+		"TF_LOG lower case": {
+			tfLog:    "debug",
+			expected: hclog.Debug,
+		},
+		"TF_LOG JSON": {
+			tfLog:    "JSON",
+			expected: hclog.Trace,
+		},
+		"TF_LOG OFF": {
+			tfLog:    "OFF",
+			expected: hclog.Off,
+		},
+		"TF_LOG invalid": {
+			tfLog:    "VERBOSE",
+			expected: hclog.Trace,
+		},
+		"TF_LOG_PROVIDER": {
+			tfLogProvider: "WARN",
+			expected:      hclog.Warn,
+		},
+		"TF_LOG_PROVIDER overrides TF_LOG": {
+			tfLog:         "TRACE",
+			tfLogProvider: "ERROR",
+			expected:      hclog.Error,
+		},
+	}
+
+	for name, testcase := range testcases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("TF_LOG", testcase.tfLog)
+			t.Setenv("TF_LOG_PROVIDER", testcase.tfLogProvider)
+
+			if a, e := tfLogLevel(), testcase.expected; a != e {
+				t.Errorf("expected level %s, got %s", e, a)
+			}
+		})
+	}
+}
+
 func tfLoggerFactory(ctx context.Context, name string, output io.Writer) (context.Context, Logger) {
 	ctx = tflogtest.RootLogger(ctx, output)
 
@@ -0,0 +1,217 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package awsbase
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// meterName is the instrumentation scope name of the meter used for AWS API calls.
+const meterName = "github.com/hashicorp/aws-sdk-go-base/v2"
+
+// credentialsSourceKey is the attribute key for the source of refreshed credentials.
+const credentialsSourceKey attribute.Key = "aws.credentials.source"
+
+type apiMetrics struct {
+	callDuration       metric.Float64Histogram
+	attempts           metric.Int64Counter
+	retries            metric.Int64Counter
+	throttles          metric.Int64Counter
+	credentialRefresh  metric.Int64Counter
+	assumeRoleDuration metric.Float64Histogram
+
+	// Throttling errors of the configured retryer, set by resolveRetryer.
+	// Until the retryer is resolved, the AWS SDK's default throttling errors are used.
+	isErrorThrottle atomic.Pointer[retry.IsErrorThrottles]
+}
+
+func newAPIMetrics(mp metric.MeterProvider) (*apiMetrics, error) {
+	meter := mp.Meter(meterName)
+
+	var m apiMetrics
+	var err error
+
+	if m.callDuration, err = meter.Float64Histogram("aws.api.call.duration",
+		metric.WithDescription("Duration of AWS API calls, including retries."),
+		metric.WithUnit("s"),
+	); err != nil {
+		return nil, err
+	}
+	if m.attempts, err = meter.Int64Counter("aws.api.call.attempts",
+		metric.WithDescription("Number of HTTP attempts made for AWS API calls."),
+		metric.WithUnit("{attempt}"),
+	); err != nil {
+		return nil, err
+	}
+	if m.retries, err = meter.Int64Counter("aws.api.call.retries",
+		metric.WithDescription("Number of AWS API call attempts which were retried."),
+		metric.WithUnit("{retry}"),
+	); err != nil {
+		return nil, err
+	}
+	if m.throttles, err = meter.Int64Counter("aws.api.call.throttles",
+		metric.WithDescription("Number of AWS API call attempts which failed with a throttling error."),
+		metric.WithUnit("{error}"),
+	); err != nil {
+		return nil, err
+	}
+	if m.credentialRefresh, err = meter.Int64Counter("aws.credentials.refreshes",
+		metric.WithDescription("Number of times AWS credentials were retrieved from their source."),
+		metric.WithUnit("{refresh}"),
+	); err != nil {
+		return nil, err
+	}
+	if m.assumeRoleDuration, err = meter.Float64Histogram("aws.sts.assume_role.duration",
+		metric.WithDescription("Duration of STS AssumeRole calls, including retries."),
+		metric.WithUnit("s"),
+	); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// setThrottles sets the throttling errors of the configured retryer.
+func (m *apiMetrics) setThrottles(throttles []retry.IsErrorThrottle) {
+	v := retry.IsErrorThrottles(throttles)
+	m.isErrorThrottle.Store(&v)
+}
+
+// throttleClassifier returns the classifier of throttling errors.
+func (m *apiMetrics) throttleClassifier() retry.IsErrorThrottles {
+	if v := m.isErrorThrottle.Load(); v != nil {
+		return *v
+	}
+
+	return retry.IsErrorThrottles(retry.DefaultThrottles)
+}
+
+// withMetrics returns an API option which records metrics for each AWS operation.
+func withMetrics(m *apiMetrics) func(*middleware.Stack) error {
+	return func(stack *middleware.Stack) error {
+		return stack.Initialize.Add(&operationMetrics{metrics: m}, middleware.After)
+	}
+}
+
+type operationMetrics struct {
+	metrics *apiMetrics
+}
+
+// ID is the middleware identifier.
+func (m *operationMetrics) ID() string {
+	return "TF_AWS_OperationMetrics"
+}
+
+func (m *operationMetrics) HandleInitialize(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (
+	out middleware.InitializeOutput, metadata middleware.Metadata, err error,
+) {
+	attributes := metric.WithAttributes(operationAttributes(ctx, in)...)
+
+	start := time.Now()
+
+	out, metadata, err = next.HandleInitialize(ctx, in)
+
+	elapsed := time.Since(start).Seconds()
+
+	m.metrics.callDuration.Record(ctx, elapsed, attributes)
+	if isAssumeRoleOperation(ctx) {
+		m.metrics.assumeRoleDuration.Record(ctx, elapsed, attributes)
+	}
+
+	if results, ok := retry.GetAttemptResults(metadata); ok {
+		throttles := m.metrics.throttleClassifier()
+
+		var retries, throttled int64
+		for _, result := range results.Results {
+			if result.Retried {
+				retries++
+			}
+			// Only errors which the client's retryer considers retryable are counted as throttling errors.
+			if result.Err != nil && result.Retryable && throttles.IsErrorThrottle(result.Err) == aws.TrueTernary {
+				throttled++
+			}
+		}
+
+		m.metrics.attempts.Add(ctx, int64(len(results.Results)), attributes)
+		m.metrics.retries.Add(ctx, retries, attributes)
+		m.metrics.throttles.Add(ctx, throttled, attributes)
+	}
+
+	return out, metadata, err
+}
+
+// isAssumeRoleOperation returns whether the operation is one of the STS AssumeRole operations.
+func isAssumeRoleOperation(ctx context.Context) bool {
+	return awsmiddleware.GetServiceID(ctx) == sts.ServiceID && strings.HasPrefix(awsmiddleware.GetOperationName(ctx), "AssumeRole")
+}
+
+type apiMetricsKeyT string
+
+const apiMetricsKey apiMetricsKeyT = "api-metrics"
+
+// withAPIMetrics returns a context carrying the metrics recorded while resolving an AWS configuration.
+func withAPIMetrics(ctx context.Context, m *apiMetrics) context.Context {
+	return context.WithValue(ctx, apiMetricsKey, m)
+}
+
+// apiMetricsFromContext returns the metrics in the context, or nil if metrics are not recorded.
+func apiMetricsFromContext(ctx context.Context) *apiMetrics {
+	m, _ := ctx.Value(apiMetricsKey).(*apiMetrics)
+	return m
+}
+
+// refreshCountingProvider is a CredentialsProvider which counts retrievals from the wrapped provider.
+// It is intended to be wrapped in an aws.CredentialsCache, so that only refreshes are counted.
+type refreshCountingProvider struct {
+	provider aws.CredentialsProvider
+	counter  metric.Int64Counter
+}
+
+func (p *refreshCountingProvider) Retrieve(ctx context.Context) (aws.Credentials, error) {
+	creds, err := p.provider.Retrieve(ctx)
+	if err != nil {
+		return creds, err
+	}
+
+	p.counter.Add(ctx, 1, metric.WithAttributes(credentialsSourceKey.String(creds.Source)))
+
+	return creds, nil
+}
+
+// withCredentialsRefreshCount returns a CredentialsProvider which counts retrievals from the given provider
+// if the context carries metrics. The result must be wrapped in an aws.CredentialsCache.
+func withCredentialsRefreshCount(ctx context.Context, provider aws.CredentialsProvider) aws.CredentialsProvider {
+	m := apiMetricsFromContext(ctx)
+	if m == nil {
+		return provider
+	}
+
+	return &refreshCountingProvider{
+		provider: provider,
+		counter:  m.credentialRefresh,
+	}
+}
+
+// newCredentialsCache returns an aws.CredentialsCache for the given provider, counting refreshes if the context carries metrics.
+// A provider which is already an aws.CredentialsCache, e.g. one created by the AWS SDK's default credential chain,
+// is only cached again when refreshes are counted. Both caches expire at the same time, so a retrieval from the outer cache's
+// provider is a refresh of the inner cache.
+func newCredentialsCache(ctx context.Context, provider aws.CredentialsProvider) *aws.CredentialsCache {
+	if cache, ok := provider.(*aws.CredentialsCache); ok && apiMetricsFromContext(ctx) == nil {
+		return cache
+	}
+
+	return aws.NewCredentialsCache(withCredentialsRefreshCount(ctx, provider))
+}
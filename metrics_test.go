@@ -0,0 +1,299 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package awsbase
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/hashicorp/aws-sdk-go-base/v2/servicemocks"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestMetrics(t *testing.T) {
+	ctx := context.Background()
+
+	servicemocks.InitSessionTestEnv(t)
+
+	reader := sdkmetric.NewManualReader()
+
+	config := &Config{
+		AccessKey: servicemocks.MockStaticAccessKey,
+		AssumeRole: []AssumeRole{{
+			RoleARN:     servicemocks.MockStsAssumeRoleArn,
+			SessionName: servicemocks.MockStsAssumeRoleSessionName,
+		}},
+		MeterProvider: sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+		Region:        "us-east-1",
+		SecretKey:     servicemocks.MockStaticSecretKey,
+	}
+
+	ts := servicemocks.MockAwsApiServer("STS", []*servicemocks.MockEndpoint{
+		servicemocks.MockStsAssumeRoleValidEndpoint,
+		servicemocks.MockStsGetCallerIdentityValidEndpoint,
+	})
+	defer ts.Close()
+	config.StsEndpoint = ts.URL
+
+	_, awsConfig, diags := GetAwsConfig(ctx, config)
+	if diags.HasError() {
+		t.Fatalf("error in GetAwsConfig(): %v", diags)
+	}
+
+	if _, ok := awsConfig.Credentials.(*aws.CredentialsCache); !ok {
+		t.Errorf("expected credentials to be cached, got %T", awsConfig.Credentials)
+	}
+
+	metrics := collectMetrics(t, reader)
+
+	assumeRole := attribute.String("rpc.method", "AssumeRole")
+	getCallerIdentity := attribute.String("rpc.method", "GetCallerIdentity")
+
+	if a := histogramCount(t, metrics, "aws.api.call.duration", assumeRole); a == 0 {
+		t.Error("expected AssumeRole call duration to be recorded")
+	}
+	if a := histogramCount(t, metrics, "aws.api.call.duration", getCallerIdentity); a == 0 {
+		t.Error("expected GetCallerIdentity call duration to be recorded")
+	}
+	if a, e := histogramCount(t, metrics, "aws.sts.assume_role.duration", assumeRole), histogramCount(t, metrics, "aws.api.call.duration", assumeRole); a != e {
+		t.Errorf("expected %d AssumeRole durations, got %d", e, a)
+	}
+	if a := histogramCount(t, metrics, "aws.sts.assume_role.duration", getCallerIdentity); a != 0 {
+		t.Errorf("expected no GetCallerIdentity AssumeRole durations, got %d", a)
+	}
+
+	if a, e := counterValue(t, metrics, "aws.api.call.attempts", getCallerIdentity), int64(1); a != e {
+		t.Errorf("expected %d GetCallerIdentity attempts, got %d", e, a)
+	}
+	if a, e := counterValue(t, metrics, "aws.api.call.retries", getCallerIdentity), int64(0); a != e {
+		t.Errorf("expected %d GetCallerIdentity retries, got %d", e, a)
+	}
+
+	if a, e := counterValue(t, metrics, "aws.credentials.refreshes", credentialsSourceKey.String("AssumeRoleProvider")), int64(1); a != e {
+		t.Errorf("expected %d credentials refreshes, got %d", e, a)
+	}
+	if a, e := counterValue(t, metrics, "aws.credentials.refreshes", credentialsSourceKey.String("StaticCredentials")), int64(1); a != e {
+		t.Errorf("expected %d static credentials refreshes, got %d", e, a)
+	}
+}
+
+func TestMetricsDefaultCredentialChainRefreshes(t *testing.T) {
+	ctx := context.Background()
+
+	servicemocks.InitSessionTestEnv(t)
+	t.Setenv("AWS_ACCESS_KEY_ID", servicemocks.MockEnvAccessKey)
+	t.Setenv("AWS_SECRET_ACCESS_KEY", servicemocks.MockEnvSecretKey)
+
+	reader := sdkmetric.NewManualReader()
+
+	config := &Config{
+		MeterProvider:       sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+		Region:              "us-east-1",
+		SkipCredsValidation: true,
+	}
+
+	_, awsConfig, diags := GetAwsConfig(ctx, config)
+	if diags.HasError() {
+		t.Fatalf("error in GetAwsConfig(): %v", diags)
+	}
+
+	// Cached credentials are not refreshed.
+	if _, err := awsConfig.Credentials.Retrieve(ctx); err != nil {
+		t.Fatalf("retrieving credentials: %s", err)
+	}
+
+	metrics := collectMetrics(t, reader)
+
+	if a, e := counterValue(t, metrics, "aws.credentials.refreshes", credentialsSourceKey.String("EnvConfigCredentials")), int64(1); a != e {
+		t.Errorf("expected %d environment credentials refreshes, got %d", e, a)
+	}
+}
+
+func TestMetricsThrottling(t *testing.T) {
+	ctx := context.Background()
+
+	servicemocks.InitSessionTestEnv(t)
+
+	reader := sdkmetric.NewManualReader()
+
+	config := &Config{
+		AccessKey:           servicemocks.MockStaticAccessKey,
+		MaxBackoff:          time.Millisecond,
+		MeterProvider:       sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+		Region:              "us-east-1",
+		SecretKey:           servicemocks.MockStaticSecretKey,
+		SkipCredsValidation: true,
+	}
+
+	_, awsConfig, diags := GetAwsConfig(ctx, config)
+	if diags.HasError() {
+		t.Fatalf("error in GetAwsConfig(): %v", diags)
+	}
+
+	// Throttles the first attempt so that the request is retried.
+	var requests atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(throttlingErrorResponseBody))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(servicemocks.MockStsGetCallerIdentityValidResponseBody))
+	}))
+	defer ts.Close()
+
+	client := sts.NewFromConfig(awsConfig, func(o *sts.Options) {
+		o.BaseEndpoint = aws.String(ts.URL)
+	})
+	if _, err := client.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{}); err != nil {
+		t.Fatalf("GetCallerIdentity: %s", err)
+	}
+
+	metrics := collectMetrics(t, reader)
+
+	getCallerIdentity := attribute.String("rpc.method", "GetCallerIdentity")
+
+	for name, e := range map[string]int64{
+		"aws.api.call.attempts":  2,
+		"aws.api.call.retries":   1,
+		"aws.api.call.throttles": 1,
+	} {
+		if a := counterValue(t, metrics, name, getCallerIdentity); a != e {
+			t.Errorf("%s: expected %d, got %d", name, e, a)
+		}
+	}
+	if a, e := histogramCount(t, metrics, "aws.api.call.duration", getCallerIdentity), uint64(1); a != e {
+		t.Errorf("expected %d call durations, got %d", e, a)
+	}
+}
+
+func TestMetricsThrottlingNotRetryable(t *testing.T) {
+	ctx := context.Background()
+
+	servicemocks.InitSessionTestEnv(t)
+
+	reader := sdkmetric.NewManualReader()
+
+	config := &Config{
+		AccessKey:           servicemocks.MockStaticAccessKey,
+		MeterProvider:       sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+		Region:              "us-east-1",
+		SecretKey:           servicemocks.MockStaticSecretKey,
+		SkipCredsValidation: true,
+	}
+
+	_, awsConfig, diags := GetAwsConfig(ctx, config)
+	if diags.HasError() {
+		t.Fatalf("error in GetAwsConfig(): %v", diags)
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(throttlingErrorResponseBody))
+	}))
+	defer ts.Close()
+
+	// The client's retryer doesn't retry any errors, so none are counted as throttling errors.
+	client := sts.NewFromConfig(awsConfig, func(o *sts.Options) {
+		o.BaseEndpoint = aws.String(ts.URL)
+		o.Retryer = aws.NopRetryer{}
+	})
+	if _, err := client.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{}); err == nil {
+		t.Fatal("expected GetCallerIdentity error, got none")
+	}
+
+	metrics := collectMetrics(t, reader)
+
+	getCallerIdentity := attribute.String("rpc.method", "GetCallerIdentity")
+
+	for name, e := range map[string]int64{
+		"aws.api.call.attempts":  1,
+		"aws.api.call.throttles": 0,
+	} {
+		if a := counterValue(t, metrics, name, getCallerIdentity); a != e {
+			t.Errorf("%s: expected %d, got %d", name, e, a)
+		}
+	}
+}
+
+const throttlingErrorResponseBody = `<ErrorResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <Error>
+    <Type>Sender</Type>
+    <Code>Throttling</Code>
+    <Message>Rate exceeded</Message>
+  </Error>
+  <RequestId>01234567-89ab-cdef-0123-456789abcdef</RequestId>
+</ErrorResponse>`
+
+func collectMetrics(t *testing.T, reader sdkmetric.Reader) []metricdata.Metrics {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("collecting metrics: %s", err)
+	}
+
+	var metrics []metricdata.Metrics
+	for _, sm := range rm.ScopeMetrics {
+		if sm.Scope.Name == meterName {
+			metrics = append(metrics, sm.Metrics...)
+		}
+	}
+	return metrics
+}
+
+// histogramCount returns the number of values recorded in the named histogram with the given attribute.
+func histogramCount(t *testing.T, metrics []metricdata.Metrics, name string, kv attribute.KeyValue) uint64 {
+	t.Helper()
+
+	var count uint64
+	for _, m := range metrics {
+		if m.Name != name {
+			continue
+		}
+		data, ok := m.Data.(metricdata.Histogram[float64])
+		if !ok {
+			t.Fatalf("%s: unexpected data type %T", name, m.Data)
+		}
+		for _, dp := range data.DataPoints {
+			if v, ok := dp.Attributes.Value(kv.Key); ok && v == kv.Value {
+				count += dp.Count
+			}
+		}
+	}
+	return count
+}
+
+// counterValue returns the sum of the named counter with the given attribute.
+func counterValue(t *testing.T, metrics []metricdata.Metrics, name string, kv attribute.KeyValue) int64 {
+	t.Helper()
+
+	var sum int64
+	for _, m := range metrics {
+		if m.Name != name {
+			continue
+		}
+		data, ok := m.Data.(metricdata.Sum[int64])
+		if !ok {
+			t.Fatalf("%s: unexpected data type %T", name, m.Data)
+		}
+		for _, dp := range data.DataPoints {
+			if v, ok := dp.Attributes.Value(kv.Key); ok && v == kv.Value {
+				sum += dp.Value
+			}
+		}
+	}
+	return sum
+}
@@ -366,5 +366,5 @@ func compareAttributeKeyValues(x, y attribute.KeyValue) bool {
 	if x.Value.Type() != y.Value.Type() {
 		return false
 	}
-	return x.Value.AsInterface() == y.Value.AsInterface()
+	return cmp.Equal(x.Value.AsInterface(), y.Value.AsInterface())
 }
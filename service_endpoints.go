@@ -0,0 +1,152 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package awsbase
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/sso"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/hashicorp/aws-sdk-go-base/v2/logging"
+)
+
+const (
+	configSourceSharedConfig = "shared_config"
+
+	endpointURLEnvVarPrefix = "AWS_ENDPOINT_URL_"
+)
+
+// serviceEndpoint returns the custom endpoint for the service with the given SDK service ID, if any.
+// The dedicated IAM, SSO, and STS endpoint fields take precedence over Endpoints,
+// which takes precedence over the emulator endpoint.
+func serviceEndpoint(c *Config, serviceID string) string {
+	switch serviceID {
+	case iam.ServiceID:
+		if c.IamEndpoint != "" {
+			return c.IamEndpoint
+		}
+	case sso.ServiceID:
+		if c.SsoEndpoint != "" {
+			return c.SsoEndpoint
+		}
+	case sts.ServiceID:
+		if c.StsEndpoint != "" {
+			return c.StsEndpoint
+		}
+	}
+
+	if v := serviceEndpoints(c.Endpoints).lookup(serviceID); v != "" {
+		return v
+	}
+
+	return c.EmulatorEndpoint
+}
+
+// serviceEndpoints is a map of custom endpoints indexed by SDK service ID.
+//
+// It implements the `ServiceBaseEndpointProvider` interface from
+// https://github.com/aws/aws-sdk-go-v2/blob/main/internal/configsources/endpoints.go,
+// so that when it is added to aws.Config.ConfigSources, every client created using `NewFromConfig`
+// uses the endpoint as its `BaseEndpoint`.
+type serviceEndpoints map[string]string
+
+func (e serviceEndpoints) GetServiceBaseEndpoint(_ context.Context, sdkID string) (string, bool, error) {
+	v := e.lookup(sdkID)
+	return v, v != "", nil
+}
+
+func (e serviceEndpoints) lookup(serviceID string) string {
+	if v, ok := e[serviceID]; ok {
+		return v
+	}
+
+	normalized := normalizeServiceID(serviceID)
+	for k, v := range e {
+		if normalizeServiceID(k) == normalized {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// normalizeServiceID normalizes an SDK service ID in the same way as the shared configuration file `services` section,
+// e.g. "API Gateway" and "API_GATEWAY" are both normalized to "api_gateway".
+func normalizeServiceID(serviceID string) string {
+	return strings.ReplaceAll(strings.ToLower(serviceID), " ", "_")
+}
+
+// addServiceEndpointsConfigSource adds the custom service endpoints to the aws.Config as the highest priority
+// configuration source for per-service base endpoints.
+func addServiceEndpointsConfigSource(awsConfig *aws.Config, c *Config) {
+	if len(c.Endpoints) == 0 {
+		return
+	}
+
+	awsConfig.ConfigSources = append([]any{serviceEndpoints(c.Endpoints)}, awsConfig.ConfigSources...)
+}
+
+// logServiceEndpoints logs the effective custom service endpoints and where each was configured.
+func logServiceEndpoints(ctx context.Context, awsConfig aws.Config, c *Config) {
+	logger := logging.RetrieveLogger(ctx)
+
+	type endpointSource struct {
+		url    string
+		source string
+	}
+	effective := make(map[string]endpointSource)
+
+	for _, source := range awsConfig.ConfigSources {
+		if sharedConfig, ok := source.(config.SharedConfig); ok {
+			for serviceID, values := range sharedConfig.Services.ServiceValues {
+				if v := values["endpoint_url"]; v != "" {
+					effective[normalizeServiceID(serviceID)] = endpointSource{
+						url:    v,
+						source: configSourceSharedConfig,
+					}
+				}
+			}
+		}
+	}
+
+	for _, kv := range os.Environ() {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok || v == "" || !strings.HasPrefix(k, endpointURLEnvVarPrefix) {
+			continue
+		}
+		effective[normalizeServiceID(strings.TrimPrefix(k, endpointURLEnvVarPrefix))] = endpointSource{
+			url:    v,
+			source: fmt.Sprintf("%s(%q)", configSourceEnvironmentVariable, k),
+		}
+	}
+
+	for serviceID, v := range c.Endpoints {
+		effective[normalizeServiceID(serviceID)] = endpointSource{
+			url:    v,
+			source: configSourceProviderConfig,
+		}
+	}
+
+	serviceIDs := make([]string, 0, len(effective))
+	for serviceID := range effective {
+		serviceIDs = append(serviceIDs, serviceID)
+	}
+	sort.Strings(serviceIDs)
+
+	for _, serviceID := range serviceIDs {
+		v := effective[serviceID]
+		logger.Debug(ctx, "Using custom service endpoint", map[string]any{
+			"tf_aws.service_endpoint.service": serviceID,
+			"tf_aws.service_endpoint.url":     v.url,
+			"tf_aws.service_endpoint.source":  v.source,
+		})
+	}
+}
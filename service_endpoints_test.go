@@ -0,0 +1,126 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package awsbase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/hashicorp/aws-sdk-go-base/v2/servicemocks"
+)
+
+func TestServiceEndpoints(t *testing.T) {
+	testcases := map[string]struct {
+		Endpoints            map[string]string
+		EnvironmentVariables map[string]string
+		ExpectedDynamoDB     string
+		ExpectedSQS          string
+	}{
+		"no endpoints": {},
+		"config": {
+			Endpoints: map[string]string{
+				"DynamoDB": "https://dynamodb.example.com",
+			},
+			ExpectedDynamoDB: "https://dynamodb.example.com",
+		},
+		"config normalized": {
+			Endpoints: map[string]string{
+				"dynamodb": "https://dynamodb.example.com",
+			},
+			ExpectedDynamoDB: "https://dynamodb.example.com",
+		},
+		"envvar": {
+			EnvironmentVariables: map[string]string{
+				"AWS_ENDPOINT_URL_SQS": "https://sqs-env.example.com",
+			},
+			ExpectedSQS: "https://sqs-env.example.com",
+		},
+		"config overrides envvar": {
+			Endpoints: map[string]string{
+				"SQS": "https://sqs-config.example.com",
+			},
+			EnvironmentVariables: map[string]string{
+				"AWS_ENDPOINT_URL_SQS": "https://sqs-env.example.com",
+			},
+			ExpectedSQS: "https://sqs-config.example.com",
+		},
+	}
+
+	for name, testcase := range testcases {
+		testcase := testcase
+
+		t.Run(name, func(t *testing.T) {
+			servicemocks.InitSessionTestEnv(t)
+
+			for k, v := range testcase.EnvironmentVariables {
+				t.Setenv(k, v)
+			}
+
+			c := &Config{
+				AccessKey:           servicemocks.MockStaticAccessKey,
+				SecretKey:           servicemocks.MockStaticSecretKey,
+				Region:              "us-east-1",
+				Endpoints:           testcase.Endpoints,
+				SkipCredsValidation: true,
+			}
+
+			_, awsConfig, diags := GetAwsConfig(context.Background(), c)
+			if diags.HasError() {
+				t.Fatalf("error in GetAwsConfig(): %v", diags)
+			}
+
+			dynamodbClient := dynamodb.NewFromConfig(awsConfig)
+			if a, e := aws.ToString(dynamodbClient.Options().BaseEndpoint), testcase.ExpectedDynamoDB; a != e {
+				t.Errorf("expected DynamoDB base endpoint %q, got %q", e, a)
+			}
+
+			sqsClient := sqs.NewFromConfig(awsConfig)
+			if a, e := aws.ToString(sqsClient.Options().BaseEndpoint), testcase.ExpectedSQS; a != e {
+				t.Errorf("expected SQS base endpoint %q, got %q", e, a)
+			}
+		})
+	}
+}
+
+func TestServiceEndpointsSTS(t *testing.T) {
+	servicemocks.InitSessionTestEnv(t)
+
+	ts := servicemocks.MockAwsApiServer("STS", []*servicemocks.MockEndpoint{
+		servicemocks.MockStsGetCallerIdentityValidEndpoint,
+	})
+	defer ts.Close()
+
+	c := &Config{
+		AccessKey: servicemocks.MockStaticAccessKey,
+		SecretKey: servicemocks.MockStaticSecretKey,
+		Region:    "us-east-1",
+		Endpoints: map[string]string{
+			"STS": ts.URL,
+		},
+	}
+
+	if _, _, diags := GetAwsConfig(context.Background(), c); diags.HasError() {
+		t.Fatalf("error in GetAwsConfig(): %v", diags)
+	}
+}
+
+func TestServiceEndpointsInvalid(t *testing.T) {
+	servicemocks.InitSessionTestEnv(t)
+
+	c := &Config{
+		AccessKey: servicemocks.MockStaticAccessKey,
+		SecretKey: servicemocks.MockStaticSecretKey,
+		Region:    "us-east-1",
+		Endpoints: map[string]string{
+			"S3": "localhost:4566",
+		},
+	}
+
+	if _, _, diags := GetAwsConfig(context.Background(), c); !diags.HasError() {
+		t.Fatal("expected error, got none")
+	}
+}
@@ -48,7 +48,7 @@ const (
   "Statement": {
     "Effect": "Allow",
     "Action": "*",
-    "Resource": "*",
+    "Resource": "*"
   }
 }`
 	MockStsAssumeRolePolicyArn         = `arn:aws:iam::555555555555:policy/AssumeRolePolicy1`
@@ -0,0 +1,358 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package awsbase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/sso"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/hashicorp/aws-sdk-go-base/v2/diag"
+	"github.com/hashicorp/aws-sdk-go-base/v2/internal/awsconfig"
+	"github.com/hashicorp/aws-sdk-go-base/v2/logging"
+)
+
+// ConfigSnapshot is a serializable representation of the non-secret parts of a resolved aws.Config.
+// It can be passed to another process and rehydrated using RestoreAwsConfig without
+// repeating credential resolution or validation.
+type ConfigSnapshot struct {
+	Region               string            `json:"region"`
+	Profile              string            `json:"profile,omitempty"`
+	EmulatorEndpoint     string            `json:"emulator_endpoint,omitempty"`
+	Endpoints            map[string]string `json:"endpoints,omitempty"`
+	UseDualStackEndpoint bool              `json:"use_dual_stack_endpoint,omitempty"`
+	UseFIPSEndpoint      bool              `json:"use_fips_endpoint,omitempty"`
+	RetryMode            aws.RetryMode     `json:"retry_mode,omitempty"`
+	MaxAttempts          int               `json:"max_attempts,omitempty"`
+
+	// CredentialsSource describes the provider which produced the credentials, e.g. "SharedConfigCredentials".
+	CredentialsSource string `json:"credentials_source,omitempty"`
+
+	// CredentialsHandle is an opaque, caller-assigned reference to the channel over which
+	// credentials are delivered to the restoring process, such as a file descriptor number.
+	CredentialsHandle string `json:"credentials_handle,omitempty"`
+
+	CallerIdentity *CallerIdentity `json:"caller_identity,omitempty"`
+}
+
+// CallerIdentity is the identity returned by sts:GetCallerIdentity.
+type CallerIdentity struct {
+	AccountID string `json:"account_id"`
+	ARN       string `json:"arn"`
+	Partition string `json:"partition"`
+	UserID    string `json:"user_id"`
+}
+
+// SnapshotAwsConfig captures the non-secret parts of an aws.Config returned by GetAwsConfig.
+// Unless SkipCredsValidation is set, the caller identity validated by GetAwsConfig is included.
+// If ctx was not returned by GetAwsConfig, the caller identity is retrieved from STS.
+func SnapshotAwsConfig(ctx context.Context, awsConfig aws.Config, c *Config) (ConfigSnapshot, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var logger logging.Logger = logging.NullLogger{}
+	if c.Logger != nil {
+		logger = c.Logger
+	}
+	logger = logging.NewMaskingLogger(logger)
+	ctx = configCommonLogging(ctx)
+	ctx, logger = logger.SubLogger(ctx, loggerName)
+	ctx = logging.RegisterLogger(ctx, logger)
+
+	snapshot := ConfigSnapshot{
+		Region:           awsConfig.Region,
+		Profile:          resolvedProfile(awsConfig),
+		EmulatorEndpoint: c.EmulatorEndpoint,
+		RetryMode:        awsConfig.RetryMode,
+	}
+
+	if endpoints := snapshotEndpoints(c); len(endpoints) > 0 {
+		snapshot.Endpoints = endpoints
+	}
+
+	if v, _, err := awsconfig.ResolveUseFIPSEndpoint(ctx, awsConfig.ConfigSources); err != nil {
+		return ConfigSnapshot{}, diags.AddSimpleError(fmt.Errorf("resolving FIPS endpoint configuration: %w", err))
+	} else {
+		snapshot.UseFIPSEndpoint = v == aws.FIPSEndpointStateEnabled
+	}
+
+	if v, _, err := awsconfig.ResolveUseDualStackEndpoint(ctx, awsConfig.ConfigSources); err != nil {
+		return ConfigSnapshot{}, diags.AddSimpleError(fmt.Errorf("resolving dual-stack endpoint configuration: %w", err))
+	} else {
+		snapshot.UseDualStackEndpoint = v == aws.DualStackEndpointStateEnabled
+	}
+
+	if awsConfig.Retryer != nil {
+		if retryer := awsConfig.Retryer(); retryer != nil {
+			snapshot.MaxAttempts = retryer.MaxAttempts()
+		}
+	}
+
+	if awsConfig.Credentials != nil {
+		creds, err := awsConfig.Credentials.Retrieve(ctx)
+		if err != nil {
+			return ConfigSnapshot{}, diags.AddSimpleError(fmt.Errorf("retrieving credentials: %w", err))
+		}
+		snapshot.CredentialsSource = creds.Source
+	}
+
+	if identity, ok := callerIdentityFromContext(ctx); ok {
+		snapshot.CallerIdentity = identity
+	} else if !c.SkipCredsValidation {
+		identity, err := getCallerIdentityFromSTS(ctx, stsClient(ctx, awsConfig, c))
+		if err != nil {
+			return ConfigSnapshot{}, diags.AddSimpleError(fmt.Errorf("validating provider credentials: %w", err))
+		}
+		snapshot.CallerIdentity = identity
+	}
+
+	logger.Debug(ctx, "Created AWS configuration snapshot", map[string]any{
+		"tf_aws.snapshot.region":             snapshot.Region,
+		"tf_aws.snapshot.credentials_source": snapshot.CredentialsSource,
+	})
+
+	return snapshot, diags
+}
+
+// resolvedProfile returns the name of the shared configuration profile loaded into awsConfig.
+// It is empty if no profile was found in the shared configuration files.
+func resolvedProfile(awsConfig aws.Config) string {
+	for _, source := range awsConfig.ConfigSources {
+		if sharedConfig, ok := source.(config.SharedConfig); ok {
+			return sharedConfig.Profile
+		}
+	}
+	return ""
+}
+
+func snapshotEndpoints(c *Config) map[string]string {
+	endpoints := make(map[string]string, len(c.Endpoints))
+
+	for serviceID, endpoint := range c.Endpoints {
+		endpoints[serviceID] = endpoint
+	}
+
+	for _, serviceID := range []string{iam.ServiceID, sso.ServiceID, sts.ServiceID} {
+		if endpoint := serviceEndpoint(c, serviceID); endpoint != "" && endpoint != c.EmulatorEndpoint {
+			endpoints[serviceID] = endpoint
+		}
+	}
+
+	return endpoints
+}
+
+type callerIdentityKeyT string
+
+const callerIdentityKey callerIdentityKeyT = "caller-identity"
+
+// withCallerIdentity records the caller identity retrieved while validating credentials,
+// so that SnapshotAwsConfig does not need to call STS again.
+func withCallerIdentity(ctx context.Context, identity *CallerIdentity) context.Context {
+	return context.WithValue(ctx, callerIdentityKey, identity)
+}
+
+func callerIdentityFromContext(ctx context.Context) (*CallerIdentity, bool) {
+	identity, ok := ctx.Value(callerIdentityKey).(*CallerIdentity)
+	return identity, ok && identity != nil
+}
+
+// RestoreAwsConfig rehydrates an aws.Config from a ConfigSnapshot.
+// Values which cannot be serialized, such as the HTTP client, logger, and User-Agent products, are taken from c.
+// Credentials are supplied by credentialsProvider and are neither retrieved nor validated.
+func RestoreAwsConfig(ctx context.Context, snapshot ConfigSnapshot, c *Config, credentialsProvider aws.CredentialsProvider) (context.Context, aws.Config, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if credentialsProvider == nil {
+		return ctx, aws.Config{}, diags.AddError("Invalid credentials provider", "A credentials provider is required to restore an AWS configuration")
+	}
+
+	var logger logging.Logger = logging.NullLogger{}
+	if c.Logger != nil {
+		logger = c.Logger
+	}
+	logger = logging.NewMaskingLogger(logger)
+	ctx = logging.RegisterLogger(ctx, logger)
+	ctx = configCommonLogging(ctx)
+
+	baseCtx, logger := logger.SubLogger(ctx, loggerName)
+	baseCtx = logging.RegisterLogger(baseCtx, logger)
+
+	logger.Trace(baseCtx, "Restoring AWS configuration from snapshot")
+
+	restored := *c
+	restored.Region = snapshot.Region
+	// The profile's non-credential settings are loaded from the restoring process's shared configuration files.
+	// Credentials are always supplied by credentialsProvider.
+	restored.Profile = snapshot.Profile
+	restored.RetryMode = snapshot.RetryMode
+	restored.MaxRetries = snapshot.MaxAttempts
+	restored.UseDualStackEndpoint = snapshot.UseDualStackEndpoint
+	restored.UseFIPSEndpoint = snapshot.UseFIPSEndpoint
+	restored.EmulatorEndpoint = snapshot.EmulatorEndpoint
+	restored.Endpoints = snapshot.Endpoints
+	restored.IamEndpoint = ""
+	restored.SsoEndpoint = ""
+	restored.StsEndpoint = ""
+
+	if c.MeterProvider != nil {
+		metrics, err := newAPIMetrics(c.MeterProvider)
+		if err != nil {
+			return ctx, aws.Config{}, diags.AddSimpleError(fmt.Errorf("creating metrics: %w", err))
+		}
+		baseCtx = withAPIMetrics(baseCtx, metrics)
+	}
+
+	loadOptions, err := commonLoadOptions(baseCtx, &restored)
+	if err != nil {
+		return ctx, aws.Config{}, diags.AddSimpleError(err)
+	}
+
+	if restored.Profile != "" {
+		loadOptions = append(
+			loadOptions,
+			config.WithSharedConfigProfile(restored.Profile),
+		)
+	}
+
+	if restored.MaxRetries != 0 {
+		loadOptions = append(
+			loadOptions,
+			config.WithRetryMaxAttempts(restored.MaxRetries),
+		)
+	}
+
+	credentialsProvider = newCredentialsCache(baseCtx, credentialsProvider)
+	loadOptions = append(
+		loadOptions,
+		config.WithCredentialsProvider(credentialsProvider),
+	)
+
+	logger.Debug(baseCtx, "Loading configuration")
+	awsConfig, err := config.LoadDefaultConfig(baseCtx, loadOptions...)
+	if err != nil {
+		return ctx, aws.Config{}, diags.AddSimpleError(fmt.Errorf("loading configuration: %w", err))
+	}
+
+	addServiceEndpointsConfigSource(&awsConfig, &restored)
+
+	resolveRetryer(baseCtx, &restored, &awsConfig)
+
+	logger.Info(baseCtx, "Restored AWS configuration from snapshot", map[string]any{
+		"tf_aws.snapshot.region":             snapshot.Region,
+		"tf_aws.snapshot.profile":            snapshot.Profile,
+		"tf_aws.snapshot.credentials_source": snapshot.CredentialsSource,
+	})
+
+	return ctx, awsConfig, diags
+}
+
+// streamCredentials is the wire format used by WriteCredentials and NewStreamCredentialsProvider.
+// It matches the output format of the credential_process shared configuration setting.
+type streamCredentials struct {
+	Version         int        `json:"Version"`
+	AccessKeyID     string     `json:"AccessKeyId"`
+	SecretAccessKey string     `json:"SecretAccessKey"`
+	SessionToken    string     `json:"SessionToken,omitempty"`
+	Expiration      *time.Time `json:"Expiration,omitempty"`
+}
+
+const streamCredentialsVersion = 1
+
+// WriteCredentials writes a single credentials document to w, typically a pipe or
+// file descriptor shared with a process using NewStreamCredentialsProvider.
+func WriteCredentials(w io.Writer, creds aws.Credentials) error {
+	doc := streamCredentials{
+		Version:         streamCredentialsVersion,
+		AccessKeyID:     creds.AccessKeyID,
+		SecretAccessKey: creds.SecretAccessKey,
+		SessionToken:    creds.SessionToken,
+	}
+	if creds.CanExpire {
+		expires := creds.Expires.UTC()
+		doc.Expiration = &expires
+	}
+
+	return json.NewEncoder(w).Encode(doc)
+}
+
+// StreamCredentialsProviderName is the source name of credentials read by NewStreamCredentialsProvider.
+const StreamCredentialsProviderName = "StreamCredentialsProvider"
+
+type streamCredentialsProvider struct {
+	once    sync.Once
+	decoder *json.Decoder
+	docs    chan streamCredentials
+	err     error
+}
+
+// NewStreamCredentialsProvider returns a credentials provider which reads credentials documents
+// written by WriteCredentials from r. Each call to Retrieve blocks until the next document is available
+// or its context is done, so the writer is expected to send refreshed credentials before the previous ones expire.
+// The provider is intended to be wrapped in an aws.CredentialsCache, as RestoreAwsConfig does.
+func NewStreamCredentialsProvider(r io.Reader) aws.CredentialsProvider {
+	return &streamCredentialsProvider{
+		decoder: json.NewDecoder(r),
+		docs:    make(chan streamCredentials),
+	}
+}
+
+// read decodes documents from the stream until it fails, handing each one to a waiting Retrieve call.
+// The decode error is recorded before docs is closed.
+func (p *streamCredentialsProvider) read() {
+	defer close(p.docs)
+
+	for {
+		var doc streamCredentials
+		if err := p.decoder.Decode(&doc); err != nil {
+			p.err = err
+			return
+		}
+		p.docs <- doc
+	}
+}
+
+func (p *streamCredentialsProvider) Retrieve(ctx context.Context) (aws.Credentials, error) {
+	p.once.Do(func() {
+		go p.read()
+	})
+
+	var doc streamCredentials
+	select {
+	case <-ctx.Done():
+		return aws.Credentials{}, fmt.Errorf("reading credentials: %w", ctx.Err())
+	case v, ok := <-p.docs:
+		if !ok {
+			return aws.Credentials{}, fmt.Errorf("reading credentials: %w", p.err)
+		}
+		doc = v
+	}
+
+	if doc.Version != streamCredentialsVersion {
+		return aws.Credentials{}, fmt.Errorf("reading credentials: unsupported version %d", doc.Version)
+	}
+	if doc.AccessKeyID == "" || doc.SecretAccessKey == "" {
+		return aws.Credentials{}, errors.New("reading credentials: missing access key or secret key")
+	}
+
+	creds := aws.Credentials{
+		AccessKeyID:     doc.AccessKeyID,
+		SecretAccessKey: doc.SecretAccessKey,
+		SessionToken:    doc.SessionToken,
+		Source:          StreamCredentialsProviderName,
+	}
+	if doc.Expiration != nil {
+		creds.CanExpire = true
+		creds.Expires = *doc.Expiration
+	}
+
+	return creds, nil
+}
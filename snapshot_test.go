@@ -0,0 +1,233 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package awsbase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/aws-sdk-go-base/v2/servicemocks"
+)
+
+func TestSnapshotAndRestoreAwsConfig(t *testing.T) {
+	servicemocks.InitSessionTestEnv(t)
+
+	ts := servicemocks.MockAwsApiServer("STS", []*servicemocks.MockEndpoint{
+		servicemocks.MockStsGetCallerIdentityValidEndpoint,
+	})
+	defer ts.Close()
+
+	c := &Config{
+		AccessKey:   servicemocks.MockStaticAccessKey,
+		SecretKey:   servicemocks.MockStaticSecretKey,
+		Region:      "us-west-2",
+		MaxRetries:  3,
+		RetryMode:   aws.RetryModeAdaptive,
+		StsEndpoint: ts.URL,
+	}
+
+	ctx, awsConfig, diags := GetAwsConfig(context.Background(), c)
+	if diags.HasError() {
+		t.Fatalf("error in GetAwsConfig(): %v", diags)
+	}
+
+	snapshot, diags := SnapshotAwsConfig(ctx, awsConfig, c)
+	if diags.HasError() {
+		t.Fatalf("error in SnapshotAwsConfig(): %v", diags)
+	}
+
+	expected := ConfigSnapshot{
+		Region:            "us-west-2",
+		Endpoints:         map[string]string{"STS": ts.URL},
+		RetryMode:         aws.RetryModeAdaptive,
+		MaxAttempts:       3,
+		CredentialsSource: credentials.StaticCredentialsName,
+		CallerIdentity: &CallerIdentity{
+			AccountID: servicemocks.MockStsGetCallerIdentityAccountID,
+			ARN:       "arn:aws:iam::222222222222:user/Alice",
+			Partition: "aws",
+			UserID:    "AKIAI44QH8DHBEXAMPLE",
+		},
+	}
+	if diff := cmp.Diff(expected, snapshot); diff != "" {
+		t.Errorf("unexpected snapshot difference: %s", diff)
+	}
+
+	b, err := json.Marshal(snapshot)
+	if err != nil {
+		t.Fatalf("marshalling snapshot: %s", err)
+	}
+	if bytes.Contains(b, []byte(servicemocks.MockStaticSecretKey)) {
+		t.Errorf("snapshot contains secret key: %s", b)
+	}
+
+	var decoded ConfigSnapshot
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("unmarshalling snapshot: %s", err)
+	}
+
+	// The restored configuration must not call STS, so the mock server is shut down first.
+	ts.Close()
+
+	var pipe bytes.Buffer
+	expires := time.Date(2099, time.December, 31, 23, 59, 59, 0, time.UTC)
+	if err := WriteCredentials(&pipe, aws.Credentials{
+		AccessKeyID:     servicemocks.MockEnvAccessKey,
+		SecretAccessKey: servicemocks.MockEnvSecretKey,
+		SessionToken:    servicemocks.MockEnvSessionToken,
+		CanExpire:       true,
+		Expires:         expires,
+	}); err != nil {
+		t.Fatalf("writing credentials: %s", err)
+	}
+
+	_, restored, diags := RestoreAwsConfig(context.Background(), decoded, &Config{}, NewStreamCredentialsProvider(&pipe))
+	if diags.HasError() {
+		t.Fatalf("error in RestoreAwsConfig(): %v", diags)
+	}
+
+	if a, e := restored.Region, "us-west-2"; a != e {
+		t.Errorf("expected region %q, got %q", e, a)
+	}
+	if a, e := restored.RetryMode, aws.RetryModeAdaptive; a != e {
+		t.Errorf("expected retry mode %q, got %q", e, a)
+	}
+	if a, e := restored.Retryer().MaxAttempts(), 3; a != e {
+		t.Errorf("expected max attempts %d, got %d", e, a)
+	}
+
+	creds, err := restored.Credentials.Retrieve(context.Background())
+	if err != nil {
+		t.Fatalf("retrieving credentials: %s", err)
+	}
+	expectedCreds := aws.Credentials{
+		AccessKeyID:     servicemocks.MockEnvAccessKey,
+		SecretAccessKey: servicemocks.MockEnvSecretKey,
+		SessionToken:    servicemocks.MockEnvSessionToken,
+		Source:          StreamCredentialsProviderName,
+		CanExpire:       true,
+		Expires:         expires,
+	}
+	if diff := cmp.Diff(expectedCreds, creds); diff != "" {
+		t.Errorf("unexpected credentials difference: %s", diff)
+	}
+}
+
+func TestSnapshotAndRestoreAwsConfigProfile(t *testing.T) {
+	servicemocks.InitSessionTestEnv(t)
+
+	sharedConfigFile := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(sharedConfigFile, []byte(`
+[profile SharedConfigurationProfile]
+region = eu-west-1
+retry_mode = adaptive
+`), 0600); err != nil {
+		t.Fatalf("writing shared configuration file: %s", err)
+	}
+
+	c := &Config{
+		AccessKey:           servicemocks.MockStaticAccessKey,
+		SecretKey:           servicemocks.MockStaticSecretKey,
+		Profile:             "SharedConfigurationProfile",
+		SharedConfigFiles:   []string{sharedConfigFile},
+		SkipCredsValidation: true,
+	}
+
+	ctx, awsConfig, diags := GetAwsConfig(context.Background(), c)
+	if diags.HasError() {
+		t.Fatalf("error in GetAwsConfig(): %v", diags)
+	}
+
+	snapshot, diags := SnapshotAwsConfig(ctx, awsConfig, c)
+	if diags.HasError() {
+		t.Fatalf("error in SnapshotAwsConfig(): %v", diags)
+	}
+
+	if a, e := snapshot.Profile, "SharedConfigurationProfile"; a != e {
+		t.Errorf("expected profile %q, got %q", e, a)
+	}
+	if a, e := snapshot.Region, "eu-west-1"; a != e {
+		t.Errorf("expected region %q, got %q", e, a)
+	}
+
+	b, err := json.Marshal(snapshot)
+	if err != nil {
+		t.Fatalf("marshalling snapshot: %s", err)
+	}
+
+	var decoded ConfigSnapshot
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("unmarshalling snapshot: %s", err)
+	}
+
+	provider := credentials.NewStaticCredentialsProvider(servicemocks.MockEnvAccessKey, servicemocks.MockEnvSecretKey, "")
+	_, restored, diags := RestoreAwsConfig(context.Background(), decoded, &Config{SharedConfigFiles: []string{sharedConfigFile}}, provider)
+	if diags.HasError() {
+		t.Fatalf("error in RestoreAwsConfig(): %v", diags)
+	}
+
+	if a, e := resolvedProfile(restored), "SharedConfigurationProfile"; a != e {
+		t.Errorf("expected restored profile %q, got %q", e, a)
+	}
+	if a, e := restored.Region, "eu-west-1"; a != e {
+		t.Errorf("expected region %q, got %q", e, a)
+	}
+	if a, e := restored.RetryMode, aws.RetryModeAdaptive; a != e {
+		t.Errorf("expected retry mode %q, got %q", e, a)
+	}
+}
+
+func TestRestoreAwsConfigRequiresCredentialsProvider(t *testing.T) {
+	servicemocks.InitSessionTestEnv(t)
+
+	_, _, diags := RestoreAwsConfig(context.Background(), ConfigSnapshot{Region: "us-east-1"}, &Config{}, nil)
+	if !diags.HasError() {
+		t.Fatal("expected error, got none")
+	}
+}
+
+func TestStreamCredentialsProviderContextCancellation(t *testing.T) {
+	r, w := io.Pipe()
+	defer w.Close()
+
+	provider := NewStreamCredentialsProvider(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := provider.Retrieve(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context deadline exceeded error, got %v", err)
+	}
+
+	go func() {
+		_ = WriteCredentials(w, aws.Credentials{
+			AccessKeyID:     servicemocks.MockEnvAccessKey,
+			SecretAccessKey: servicemocks.MockEnvSecretKey,
+		})
+	}()
+
+	creds, err := provider.Retrieve(context.Background())
+	if err != nil {
+		t.Fatalf("retrieving credentials: %s", err)
+	}
+	if a, e := creds.AccessKeyID, servicemocks.MockEnvAccessKey; a != e {
+		t.Errorf("expected access key %q, got %q", e, a)
+	}
+
+	w.Close()
+
+	if _, err := provider.Retrieve(context.Background()); !errors.Is(err, io.EOF) {
+		t.Errorf("expected EOF error, got %v", err)
+	}
+}
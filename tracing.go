@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package awsbase
+
+import (
+	"context"
+	"fmt"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/hashicorp/aws-sdk-go-base/v2/logging"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/semconv/v1.17.0/httpconv"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation scope name of the tracer used for AWS API calls.
+const tracerName = "github.com/hashicorp/aws-sdk-go-base/v2"
+
+// withTracing returns API options which create a span for each AWS operation and a child span for each HTTP attempt.
+func withTracing(tp trace.TracerProvider) []func(*middleware.Stack) error {
+	tracer := tp.Tracer(tracerName)
+
+	return []func(*middleware.Stack) error{
+		func(stack *middleware.Stack) error {
+			return stack.Initialize.Add(&operationTracer{tracer: tracer}, middleware.After)
+		},
+		func(stack *middleware.Stack) error {
+			return stack.Deserialize.Add(&attemptTracer{tracer: tracer}, middleware.Before)
+		},
+	}
+}
+
+type operationTracer struct {
+	tracer trace.Tracer
+}
+
+// ID is the middleware identifier.
+func (t *operationTracer) ID() string {
+	return "TF_AWS_OperationTracer"
+}
+
+func (t *operationTracer) HandleInitialize(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (
+	out middleware.InitializeOutput, metadata middleware.Metadata, err error,
+) {
+	ctx, span := t.tracer.Start(ctx, spanName(awsmiddleware.GetServiceID(ctx), awsmiddleware.GetOperationName(ctx)),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(operationAttributes(ctx, in)...),
+	)
+	defer span.End()
+
+	out, metadata, err = next.HandleInitialize(ctx, in)
+
+	if requestID, ok := awsmiddleware.GetRequestIDMetadata(metadata); ok {
+		span.SetAttributes(otelaws.RequestIDAttr(requestID))
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return out, metadata, err
+}
+
+type attemptTracer struct {
+	tracer trace.Tracer
+}
+
+// ID is the middleware identifier.
+func (t *attemptTracer) ID() string {
+	return "TF_AWS_AttemptTracer"
+}
+
+func (t *attemptTracer) HandleDeserialize(ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler) (
+	out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+) {
+	smithyRequest, ok := in.Request.(*smithyhttp.Request)
+	if !ok {
+		return out, metadata, fmt.Errorf("unknown request type %T", in.Request)
+	}
+
+	attributes := httpconv.ClientRequest(smithyRequest.Request)
+	if resendAttribute, ok := logging.RequestResendCountAttribute(smithyRequest.Request); ok {
+		attributes = append(attributes, resendAttribute)
+	}
+
+	ctx, span := t.tracer.Start(ctx, smithyRequest.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attributes...),
+	)
+	defer span.End()
+
+	out, metadata, err = next.HandleDeserialize(ctx, in)
+
+	if smithyResponse, ok := out.RawResponse.(*smithyhttp.Response); ok {
+		span.SetAttributes(httpconv.ClientResponse(smithyResponse.Response)...)
+		span.SetStatus(httpconv.ClientStatus(smithyResponse.StatusCode))
+	}
+	if requestID, ok := awsmiddleware.GetRequestIDMetadata(metadata); ok {
+		span.SetAttributes(otelaws.RequestIDAttr(requestID))
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return out, metadata, err
+}
+
+func spanName(serviceID, operationName string) string {
+	if operationName == "" {
+		return serviceID
+	}
+	return serviceID + "." + operationName
+}
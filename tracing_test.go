@@ -0,0 +1,198 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package awsbase
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/hashicorp/aws-sdk-go-base/v2/servicemocks"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// mockRequestID is the request ID returned by servicemocks.MockAwsApiServer.
+const mockRequestID = "1b206dd1-f9a8-11e5-becf-051c60f11c4a"
+
+func TestTracing(t *testing.T) {
+	ctx := context.Background()
+
+	servicemocks.InitSessionTestEnv(t)
+
+	recorder := tracetest.NewSpanRecorder()
+
+	config := &Config{
+		AccessKey: servicemocks.MockStaticAccessKey,
+		AssumeRole: []AssumeRole{{
+			RoleARN:     servicemocks.MockStsAssumeRoleArn,
+			SessionName: servicemocks.MockStsAssumeRoleSessionName,
+		}},
+		Region:         "us-east-1",
+		SecretKey:      servicemocks.MockStaticSecretKey,
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)),
+	}
+
+	ts := servicemocks.MockAwsApiServer("STS", []*servicemocks.MockEndpoint{
+		servicemocks.MockStsAssumeRoleValidEndpoint,
+		servicemocks.MockStsGetCallerIdentityValidEndpoint,
+	})
+	defer ts.Close()
+	config.StsEndpoint = ts.URL
+
+	_, _, diags := GetAwsConfig(ctx, config)
+	if diags.HasError() {
+		t.Fatalf("error in GetAwsConfig(): %v", diags)
+	}
+
+	operationSpan := findSpan(t, recorder.Ended(), "STS.AssumeRole")
+
+	if a, e := operationSpan.SpanKind(), trace.SpanKindClient; a != e {
+		t.Errorf("expected span kind %s, got %s", e, a)
+	}
+	for k, e := range map[attribute.Key]string{
+		"rpc.system":     "aws-api",
+		"rpc.service":    "STS",
+		"rpc.method":     "AssumeRole",
+		"aws.region":     "us-east-1",
+		"aws.request_id": mockRequestID,
+	} {
+		if a := spanAttribute(operationSpan, k).AsString(); a != e {
+			t.Errorf("expected attribute %q to be %q, got %q", k, e, a)
+		}
+	}
+	if a, e := operationSpan.Status().Code, codes.Unset; a != e {
+		t.Errorf("expected status %s, got %s", e, a)
+	}
+
+	attemptSpan := findSpan(t, recorder.Ended(), http.MethodPost)
+
+	if a, e := attemptSpan.Parent().SpanID(), operationSpan.SpanContext().SpanID(); a != e {
+		t.Errorf("expected attempt span parent %s, got %s", e, a)
+	}
+	if a, e := spanAttribute(attemptSpan, "http.status_code").AsInt64(), int64(http.StatusOK); a != e {
+		t.Errorf("expected status code %d, got %d", e, a)
+	}
+	if a, e := spanAttribute(attemptSpan, "aws.request_id").AsString(), mockRequestID; a != e {
+		t.Errorf("expected request ID %q, got %q", e, a)
+	}
+}
+
+func TestTracingError(t *testing.T) {
+	ctx := context.Background()
+
+	servicemocks.InitSessionTestEnv(t)
+
+	recorder := tracetest.NewSpanRecorder()
+
+	config := &Config{
+		AccessKey:      servicemocks.MockStaticAccessKey,
+		Region:         "us-east-1",
+		SecretKey:      servicemocks.MockStaticSecretKey,
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)),
+	}
+
+	ts := servicemocks.MockAwsApiServer("STS", []*servicemocks.MockEndpoint{
+		servicemocks.MockStsGetCallerIdentityInvalidEndpointAccessDenied,
+	})
+	defer ts.Close()
+	config.StsEndpoint = ts.URL
+
+	// Credentials validation fails with AccessDenied
+	_, _, diags := GetAwsConfig(ctx, config)
+	if !diags.HasError() {
+		t.Fatal("expected error, got none")
+	}
+
+	for _, name := range []string{"STS.GetCallerIdentity", http.MethodPost} {
+		span := findSpan(t, recorder.Ended(), name)
+
+		if a, e := span.Status().Code, codes.Error; a != e {
+			t.Errorf("%s: expected status %s, got %s", name, e, a)
+		}
+		if l := len(span.Events()); l != 1 {
+			t.Errorf("%s: expected 1 error event, got %d", name, l)
+		}
+		if a, e := spanAttribute(span, "aws.request_id").AsString(), mockRequestID; a != e {
+			t.Errorf("%s: expected request ID %q, got %q", name, e, a)
+		}
+	}
+}
+
+func TestAttemptTracerResendCount(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]struct {
+		header   string
+		expected attribute.Value
+	}{
+		"first attempt": {
+			header: "attempt=1; max=3",
+		},
+
+		"retry": {
+			header:   "attempt=2; max=3",
+			expected: attribute.IntValue(2),
+		},
+	}
+
+	for name, testcase := range testcases {
+		testcase := testcase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder := tracetest.NewSpanRecorder()
+			tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer(tracerName)
+
+			req := smithyhttp.NewStackRequest().(*smithyhttp.Request)
+			req.Method = http.MethodPost
+			req.URL.Scheme = "https"
+			req.URL.Host = "sts.us-east-1.amazonaws.com"
+			req.Header.Set("Amz-Sdk-Request", testcase.header)
+
+			next := middleware.DeserializeHandlerFunc(func(ctx context.Context, in middleware.DeserializeInput) (middleware.DeserializeOutput, middleware.Metadata, error) {
+				return middleware.DeserializeOutput{}, middleware.Metadata{}, nil
+			})
+
+			m := &attemptTracer{tracer: tracer}
+			if _, _, err := m.HandleDeserialize(context.Background(), middleware.DeserializeInput{Request: req}, next); err != nil {
+				t.Fatalf("handling request: %s", err)
+			}
+
+			span := findSpan(t, recorder.Ended(), http.MethodPost)
+			if a, e := spanAttribute(span, "http.resend_count"), testcase.expected; a != e {
+				t.Errorf("expected resend count %v, got %v", e.Emit(), a.Emit())
+			}
+		})
+	}
+}
+
+func findSpan(t *testing.T, spans []sdktrace.ReadOnlySpan, name string) sdktrace.ReadOnlySpan {
+	t.Helper()
+
+	for _, span := range spans {
+		if span.Name() == name {
+			return span
+		}
+	}
+
+	t.Fatalf("span %q not found", name)
+
+	return nil
+}
+
+func spanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
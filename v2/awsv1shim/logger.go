@@ -4,14 +4,12 @@
 package awsv1shim
 
 import (
-	"bufio"
 	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
-	"net/textproto"
 	"strings"
 	"time"
 
@@ -24,9 +22,9 @@ import (
 	"go.opentelemetry.io/otel/semconv/v1.17.0/httpconv"
 )
 
-const (
-	responseBufferLen = logging.MaxResponseBodyLen + 1024
-)
+// responseBufferPadding is the length of the response body buffered in addition to the logged length,
+// allowing the logged body to be truncated on a line boundary.
+const responseBufferPadding = 1024
 
 type debugLogger struct{}
 
@@ -43,6 +41,17 @@ func (l debugLogger) Log(args ...interface{}) {
 }
 
 func setAWSFields(ctx context.Context, r *request.Request) context.Context {
+	for _, attribute := range operationAttributes(r) {
+		ctx = tflog.SetField(ctx, string(attribute.Key), attribute.Value.AsInterface())
+	}
+
+	ctx = logging.WithOperation(ctx, r.ClientInfo.ServiceID, r.Operation.Name)
+
+	return ctx
+}
+
+// operationAttributes returns the attributes describing the AWS operation of the request.
+func operationAttributes(r *request.Request) []attribute.KeyValue {
 	region := aws.StringValue(r.Config.Region)
 
 	attributes := []attribute.KeyValue{
@@ -56,11 +65,7 @@ func setAWSFields(ctx context.Context, r *request.Request) context.Context {
 		attributes = append(attributes, logging.SigningRegion(signingRegion))
 	}
 
-	for _, attribute := range attributes {
-		ctx = tflog.SetField(ctx, string(attribute.Key), attribute.Value.AsInterface())
-	}
-
-	return ctx
+	return attributes
 }
 
 const awsSdkGoV1Val = "aws-sdk-go"
@@ -73,6 +78,36 @@ type durationKeyT string
 
 const durationKey durationKeyT = "request-duration"
 
+// bodyLogLimits returns a handler which sets the maximum lengths of logged HTTP request and response bodies.
+func bodyLogLimits(requestLen, responseLen int) request.NamedHandler {
+	return request.NamedHandler{
+		Name: "TF_AWS_BodyLogLimits",
+		Fn: func(r *request.Request) {
+			r.SetContext(logging.WithBodyLogLimits(r.Context(), requestLen, responseLen))
+		},
+	}
+}
+
+type debugLogEnabledKeyT string
+
+const debugLogEnabledKey debugLogEnabledKeyT = "debug-log-enabled"
+
+// debugLogEnabled returns whether the request and response logging handlers output their Debug messages.
+// The handlers log using terraform-plugin-log, so when Debug messages are not output,
+// the handlers skip decomposing the request and buffering the response body.
+// The result is cached in the request's context, so every attempt and the response are handled alike.
+func debugLogEnabled(r *request.Request) bool {
+	ctx := r.Context()
+	if enabled, ok := ctx.Value(debugLogEnabledKey).(bool); ok {
+		return enabled
+	}
+
+	enabled := logging.Enabled(ctx, logging.TfLogger(""), logging.LevelDebug)
+	r.SetContext(context.WithValue(ctx, debugLogEnabledKey, enabled))
+
+	return enabled
+}
+
 // Replaces the built-in logging middleware from https://github.com/aws/aws-sdk-go/blob/main/aws/client/logger.go
 // We want access to the request struct, and cannot get it from the built-in.
 // The typical route of adding logging to the http.RoundTripper doesn't work for the AWS SDK for Go v1 without forcing us to manually implement
@@ -83,6 +118,10 @@ var requestLogger = request.NamedHandler{
 }
 
 func logRequest(r *request.Request) {
+	if !debugLogEnabled(r) {
+		return
+	}
+
 	ctx := r.Context()
 
 	ctx = setAWSFields(ctx, r)
@@ -123,6 +162,10 @@ var responseLogger = request.NamedHandler{
 }
 
 func logResponse(r *request.Request) {
+	if !debugLogEnabled(r) {
+		return
+	}
+
 	ctx := r.Context()
 
 	ctx = setAWSFields(ctx, r)
@@ -135,7 +178,7 @@ func logResponse(r *request.Request) {
 	bodyBuffer := bytes.NewBuffer(nil)
 
 	r.HTTPResponse.Body = &teeReaderCloser{
-		Reader: io.TeeReader(r.HTTPResponse.Body, limitWriter(bodyBuffer, responseBufferLen)),
+		Reader: io.TeeReader(r.HTTPResponse.Body, limitWriter(bodyBuffer, int64(logging.ResponseBodyLogLimit(ctx)+responseBufferPadding))),
 		Source: r.HTTPResponse.Body,
 	}
 
@@ -149,7 +192,7 @@ func logResponse(r *request.Request) {
 
 		ctx = setAWSFields(ctx, r)
 
-		responseFields, err := decomposeHTTPResponse(r.HTTPResponse, bodyBuffer, elapsed)
+		responseFields, err := decomposeHTTPResponse(ctx, r.HTTPResponse, bodyBuffer, elapsed)
 		if err != nil {
 			tflog.Error(ctx, fmt.Sprintf("decomposing response: %s", err))
 			return
@@ -179,7 +222,7 @@ func (reader *teeReaderCloser) Close() error {
 	return reader.Source.Close()
 }
 
-func decomposeHTTPResponse(resp *http.Response, body io.Reader, elapsed time.Duration) (map[string]any, error) {
+func decomposeHTTPResponse(ctx context.Context, resp *http.Response, body io.Reader, elapsed time.Duration) (map[string]any, error) {
 	var attributes []attribute.KeyValue
 
 	attributes = append(attributes, attribute.Int64("http.duration", elapsed.Milliseconds()))
@@ -188,11 +231,17 @@ func decomposeHTTPResponse(resp *http.Response, body io.Reader, elapsed time.Dur
 
 	attributes = append(attributes, logging.DecomposeResponseHeaders(resp)...)
 
-	bodyAttribute, err := decomposeResponseBody(body)
+	attributes = logging.RedactionRuleForContext(ctx).RedactAttributes(attributes)
+
+	// The response body has already been read, so log the buffered copy
+	bodyResp := *resp
+	bodyResp.Body = io.NopCloser(body)
+
+	bodyLogger := logging.ResponseBodyLoggerFor(ctx)
+	err := bodyLogger.Log(ctx, &bodyResp, &attributes)
 	if err != nil {
 		return nil, err
 	}
-	attributes = append(attributes, bodyAttribute)
 
 	result := make(map[string]any, len(attributes))
 	for _, attribute := range attributes {
@@ -202,22 +251,6 @@ func decomposeHTTPResponse(resp *http.Response, body io.Reader, elapsed time.Dur
 	return result, nil
 }
 
-func decomposeResponseBody(bodyReader io.Reader) (kv attribute.KeyValue, err error) {
-	content, err := io.ReadAll(bodyReader)
-	if err != nil {
-		return kv, err
-	}
-
-	reader := textproto.NewReader(bufio.NewReader(bytes.NewReader(content)))
-
-	body, err := logging.ReadTruncatedBody(reader, logging.MaxResponseBodyLen)
-	if err != nil {
-		return kv, err
-	}
-
-	return attribute.String("http.response.body", body), nil
-}
-
 func limitWriter(w io.Writer, n int64) io.Writer {
 	return &limitedWriter{w, n}
 }
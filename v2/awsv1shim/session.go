@@ -56,6 +56,11 @@ func getSessionOptions(ctx context.Context, awsC *awsv2.Config, c *awsbase.Confi
 		SharedConfigFiles: append(c.SharedCredentialsFiles, c.SharedConfigFiles...),
 	}
 
+	if c.EmulatorMode() {
+		options.Config.Endpoint = aws.String(c.EmulatorEndpoint)
+		options.Config.S3ForcePathStyle = aws.Bool(true)
+	}
+
 	if !c.SuppressDebugLog {
 		options.Config.LogLevel = aws.LogLevel(aws.LogOff)
 		options.Config.Logger = debugLogger{}
@@ -88,6 +93,7 @@ func GetSession(ctx context.Context, awsC *awsv2.Config, c *awsbase.Config) (*se
 	if c.Logger != nil {
 		logger = c.Logger
 	}
+	logger = logging.NewMaskingLogger(logger)
 	ctx, logger = logger.SubLogger(ctx, loggerName)
 	ctx = logging.RegisterLogger(ctx, logger)
 
@@ -114,10 +120,15 @@ func GetSession(ctx context.Context, awsC *awsv2.Config, c *awsbase.Config) (*se
 	sess.Handlers.Build.PushBack(userAgentFromContextHandler)
 
 	if !c.SuppressDebugLog {
+		sess.Handlers.Build.PushBackNamed(bodyLogLimits(c.MaxRequestBodyLogLength, c.MaxResponseBodyLogLength))
 		sess.Handlers.Send.PushFrontNamed(requestLogger)
 		sess.Handlers.Send.PushBackNamed(responseLogger)
 	}
 
+	if c.TracerProvider != nil {
+		addTracingHandlers(&sess.Handlers, c.TracerProvider)
+	}
+
 	// Add custom input from ENV to the User-Agent request header
 	// Reference: https://github.com/terraform-providers/terraform-provider-aws/issues/9149
 	if v := os.Getenv(constants.AppendUserAgentEnvVar); v != "" {
@@ -29,6 +29,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	awsbase "github.com/hashicorp/aws-sdk-go-base/v2"
@@ -89,6 +90,35 @@ func TestGetSessionOptions(t *testing.T) {
 	}
 }
 
+func TestGetSessionOptionsEmulatorMode(t *testing.T) {
+	servicemocks.InitSessionTestEnv(t)
+
+	ctx := test.Context(t)
+
+	c := &awsbase.Config{
+		EmulatorEndpoint:    "http://localhost:4566",
+		Region:              "us-east-1",
+		SkipCredsValidation: true,
+	}
+
+	ctx, awsConfig, diags := awsbase.GetAwsConfig(ctx, c)
+	if diags.HasError() {
+		t.Fatalf("GetAwsConfig() resulted in an error %v", diags)
+	}
+
+	opts, err := getSessionOptions(ctx, &awsConfig, c)
+	if err != nil {
+		t.Fatalf("getSessionOptions() resulted in an error %s", err)
+	}
+
+	if a, e := aws.StringValue(opts.Config.Endpoint), "http://localhost:4566"; a != e {
+		t.Errorf("expected endpoint %q, got %q", e, a)
+	}
+	if !aws.BoolValue(opts.Config.S3ForcePathStyle) {
+		t.Error("expected S3 path-style addressing to be forced")
+	}
+}
+
 // End-to-end testing for GetSession
 func TestGetSession(t *testing.T) {
 	testCases := map[string]struct {
@@ -2610,6 +2640,78 @@ func TestLogger(t *testing.T) {
 	}
 }
 
+func TestLoggerCredentialMasking(t *testing.T) {
+	var buf bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &buf)
+
+	servicemocks.InitSessionTestEnv(t)
+
+	ctx, logger := logging.NewTfLogger(ctx)
+
+	config := &awsbase.Config{
+		AccessKey: servicemocks.MockStaticAccessKey,
+		Logger:    logger,
+		Region:    "us-east-1",
+		SecretKey: servicemocks.MockStaticSecretKey,
+	}
+
+	ts := servicemocks.MockAwsApiServer("STS", []*servicemocks.MockEndpoint{
+		servicemocks.MockStsAssumeRoleValidEndpoint,
+		servicemocks.MockStsGetCallerIdentityValidEndpoint,
+	})
+	defer ts.Close()
+	config.StsEndpoint = ts.URL
+
+	ctx, awsConfig, diags := awsbase.GetAwsConfig(ctx, config)
+	if diags.HasError() {
+		t.Fatalf("error in GetAwsConfig(): %v", diags)
+	}
+
+	sess, ds := GetSession(ctx, &awsConfig, config)
+	if ds.HasError() {
+		t.Fatalf("error in GetSession(): %v", ds)
+	}
+
+	buf.Reset()
+
+	_, err := sts.New(sess, &aws.Config{Endpoint: aws.String(ts.URL)}).AssumeRoleWithContext(ctx, &sts.AssumeRoleInput{
+		DurationSeconds: aws.Int64(900),
+		RoleArn:         aws.String(servicemocks.MockStsAssumeRoleArn),
+		RoleSessionName: aws.String(servicemocks.MockStsAssumeRoleSessionName),
+	})
+	if err != nil {
+		t.Fatalf("AssumeRole: %s", err)
+	}
+
+	lines, err := tflogtest.MultilineJSONDecode(&buf)
+	if err != nil {
+		t.Fatalf("decoding log lines: %s", err)
+	}
+
+	var responseLines []map[string]any
+	for _, line := range lines {
+		if line["@message"] == "HTTP Response Received" {
+			responseLines = append(responseLines, line)
+		}
+	}
+	if len(responseLines) != 1 {
+		t.Fatalf("expected 1 response line, got %d", len(responseLines))
+	}
+
+	body, ok := responseLines[0]["http.response.body"].(string)
+	if !ok {
+		t.Fatalf("expected response body, got %v", responseLines[0]["http.response.body"])
+	}
+	for _, v := range []string{servicemocks.MockStsAssumeRoleSecretKey, servicemocks.MockStsAssumeRoleSessionToken} {
+		if strings.Contains(body, v) {
+			t.Errorf("expected %q to be masked in response body: %s", v, body)
+		}
+	}
+	if !strings.Contains(body, servicemocks.MockStsAssumeRoleAccessKey) {
+		t.Errorf("expected access key ID in response body: %s", body)
+	}
+}
+
 func TestS3UsEast1RegionalEndpoint(t *testing.T) {
 	testCases := map[string]struct {
 		Config                            *awsbase.Config
@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package awsv1shim
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/hashicorp/aws-sdk-go-base/v2/logging"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/semconv/v1.17.0/httpconv"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation scope name of the tracer used for AWS API calls.
+const tracerName = "github.com/hashicorp/aws-sdk-go-base/v2/awsv1shim/v2"
+
+type spanKeyT string
+
+const (
+	operationSpanKey spanKeyT = "operation-span"
+	attemptSpanKey   spanKeyT = "attempt-span"
+)
+
+// addTracingHandlers adds handlers which create a span for each AWS operation and a child span for each HTTP attempt.
+// The operation span is started when the request is first signed and ended when the request completes,
+// so the spans match those created by the AWS SDK for Go v2 middleware.
+func addTracingHandlers(handlers *request.Handlers, tp trace.TracerProvider) {
+	tracer := tp.Tracer(tracerName)
+
+	handlers.Sign.PushFrontNamed(request.NamedHandler{
+		Name: "TF_AWS_OperationTracerStart",
+		Fn: func(r *request.Request) {
+			ctx := r.Context()
+			if _, ok := ctx.Value(operationSpanKey).(trace.Span); ok {
+				return
+			}
+
+			ctx, span := tracer.Start(ctx, spanName(r.ClientInfo.ServiceID, r.Operation.Name),
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(operationAttributes(r)...),
+			)
+			r.SetContext(context.WithValue(ctx, operationSpanKey, span))
+		},
+	})
+
+	handlers.Send.PushFrontNamed(request.NamedHandler{
+		Name: "TF_AWS_AttemptTracerStart",
+		Fn: func(r *request.Request) {
+			ctx := r.Context()
+
+			// Each attempt is a child of the operation span, not of the previous attempt.
+			if span, ok := ctx.Value(operationSpanKey).(trace.Span); ok {
+				ctx = trace.ContextWithSpan(ctx, span)
+			}
+
+			attributes := httpconv.ClientRequest(r.HTTPRequest)
+			if resendAttribute, ok := logging.ResendCountAttribute(r.RetryCount + 1); ok {
+				attributes = append(attributes, resendAttribute)
+			}
+
+			ctx, span := tracer.Start(ctx, r.HTTPRequest.Method,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attributes...),
+			)
+			r.SetContext(context.WithValue(ctx, attemptSpanKey, span))
+		},
+	})
+
+	handlers.CompleteAttempt.PushBackNamed(request.NamedHandler{
+		Name: "TF_AWS_AttemptTracerEnd",
+		Fn: func(r *request.Request) {
+			span, ok := r.Context().Value(attemptSpanKey).(trace.Span)
+			if !ok {
+				return
+			}
+
+			if resp := r.HTTPResponse; resp != nil && resp.StatusCode != 0 {
+				span.SetAttributes(httpconv.ClientResponse(resp)...)
+				span.SetStatus(httpconv.ClientStatus(resp.StatusCode))
+			}
+			endSpan(span, r)
+		},
+	})
+
+	handlers.Complete.PushBackNamed(request.NamedHandler{
+		Name: "TF_AWS_OperationTracerEnd",
+		Fn: func(r *request.Request) {
+			span, ok := r.Context().Value(operationSpanKey).(trace.Span)
+			if !ok {
+				return
+			}
+
+			endSpan(span, r)
+		},
+	})
+}
+
+// endSpan records the request ID and any error of the request on the span and ends it.
+func endSpan(span trace.Span, r *request.Request) {
+	if r.RequestID != "" {
+		span.SetAttributes(otelaws.RequestIDAttr(r.RequestID))
+	}
+	if err := r.Error; err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func spanName(serviceID, operationName string) string {
+	if operationName == "" {
+		return serviceID
+	}
+	return serviceID + "." + operationName
+}
@@ -0,0 +1,214 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package awsv1shim
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sts"
+	awsbase "github.com/hashicorp/aws-sdk-go-base/v2"
+	"github.com/hashicorp/aws-sdk-go-base/v2/servicemocks"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const mockRequestID = "1b206dd1-f9a8-11e5-becf-051c60f11c4a"
+
+func TestTracing(t *testing.T) {
+	ctx := context.Background()
+
+	servicemocks.InitSessionTestEnv(t)
+
+	recorder := tracetest.NewSpanRecorder()
+
+	config := &awsbase.Config{
+		AccessKey:      servicemocks.MockStaticAccessKey,
+		Region:         "us-east-1",
+		SecretKey:      servicemocks.MockStaticSecretKey,
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)),
+	}
+
+	ts := servicemocks.MockAwsApiServer("STS", []*servicemocks.MockEndpoint{
+		servicemocks.MockStsGetCallerIdentityValidEndpoint,
+	})
+	defer ts.Close()
+	config.StsEndpoint = ts.URL
+
+	ctx, awsConfig, diags := awsbase.GetAwsConfig(ctx, config)
+	if diags.HasError() {
+		t.Fatalf("error in GetAwsConfig(): %v", diags)
+	}
+
+	sess, ds := GetSession(ctx, &awsConfig, config)
+	if ds.HasError() {
+		t.Fatalf("error in GetSession(): %v", ds)
+	}
+
+	// Fails the first attempt so that the request is retried.
+	var requests atomic.Int32
+	retryServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		w.Header().Set("X-Amzn-Requestid", mockRequestID)
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(servicemocks.MockStsGetCallerIdentityValidResponseBody))
+	}))
+	defer retryServer.Close()
+
+	_, err := sts.New(sess, &aws.Config{Endpoint: aws.String(retryServer.URL)}).GetCallerIdentityWithContext(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		t.Fatalf("GetCallerIdentity: %s", err)
+	}
+
+	var operationSpans, attemptSpans []sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		if span.InstrumentationScope().Name != tracerName {
+			continue
+		}
+		switch span.Name() {
+		case "STS.GetCallerIdentity":
+			operationSpans = append(operationSpans, span)
+		case http.MethodPost:
+			attemptSpans = append(attemptSpans, span)
+		default:
+			t.Errorf("unexpected span %q", span.Name())
+		}
+	}
+
+	if l := len(operationSpans); l != 1 {
+		t.Fatalf("expected 1 operation span, got %d", l)
+	}
+	operationSpan := operationSpans[0]
+
+	if a, e := operationSpan.SpanKind(), trace.SpanKindClient; a != e {
+		t.Errorf("expected span kind %s, got %s", e, a)
+	}
+	for k, e := range map[attribute.Key]string{
+		"rpc.system":     "aws-api",
+		"rpc.service":    "STS",
+		"rpc.method":     "GetCallerIdentity",
+		"aws.region":     "us-east-1",
+		"aws.request_id": mockRequestID,
+	} {
+		if a := spanAttribute(operationSpan, k).AsString(); a != e {
+			t.Errorf("expected attribute %q to be %q, got %q", k, e, a)
+		}
+	}
+	if a, e := operationSpan.Status().Code, codes.Unset; a != e {
+		t.Errorf("expected status %s, got %s", e, a)
+	}
+
+	if l := len(attemptSpans); l != 2 { //nolint:mnd
+		t.Fatalf("expected 2 attempt spans, got %d", l)
+	}
+	for i, span := range attemptSpans {
+		if a, e := span.Parent().SpanID(), operationSpan.SpanContext().SpanID(); a != e {
+			t.Errorf("attempt %d: expected parent %s, got %s", i+1, e, a)
+		}
+	}
+
+	first, second := attemptSpans[0], attemptSpans[1]
+
+	if a, e := spanAttribute(first, "http.status_code").AsInt64(), int64(http.StatusServiceUnavailable); a != e {
+		t.Errorf("first attempt: expected status code %d, got %d", e, a)
+	}
+	if a, e := first.Status().Code, codes.Error; a != e {
+		t.Errorf("first attempt: expected status %s, got %s", e, a)
+	}
+	if a := spanAttribute(first, "http.resend_count"); a.Type() != attribute.INVALID {
+		t.Errorf("first attempt: expected no resend count, got %v", a.Emit())
+	}
+
+	if a, e := spanAttribute(second, "http.status_code").AsInt64(), int64(http.StatusOK); a != e {
+		t.Errorf("second attempt: expected status code %d, got %d", e, a)
+	}
+	if a, e := spanAttribute(second, "http.resend_count").AsInt64(), int64(2); a != e { //nolint:mnd
+		t.Errorf("second attempt: expected resend count %d, got %d", e, a)
+	}
+	if a, e := spanAttribute(second, "aws.request_id").AsString(), mockRequestID; a != e {
+		t.Errorf("second attempt: expected request ID %q, got %q", e, a)
+	}
+}
+
+func TestTracingError(t *testing.T) {
+	ctx := context.Background()
+
+	servicemocks.InitSessionTestEnv(t)
+
+	recorder := tracetest.NewSpanRecorder()
+
+	config := &awsbase.Config{
+		AccessKey:      servicemocks.MockStaticAccessKey,
+		Region:         "us-east-1",
+		SecretKey:      servicemocks.MockStaticSecretKey,
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)),
+	}
+
+	ts := servicemocks.MockAwsApiServer("STS", []*servicemocks.MockEndpoint{
+		servicemocks.MockStsGetCallerIdentityValidEndpoint,
+	})
+	defer ts.Close()
+	config.StsEndpoint = ts.URL
+
+	ctx, awsConfig, diags := awsbase.GetAwsConfig(ctx, config)
+	if diags.HasError() {
+		t.Fatalf("error in GetAwsConfig(): %v", diags)
+	}
+
+	sess, ds := GetSession(ctx, &awsConfig, config)
+	if ds.HasError() {
+		t.Fatalf("error in GetSession(): %v", ds)
+	}
+
+	errorServer := servicemocks.MockAwsApiServer("STS", []*servicemocks.MockEndpoint{
+		servicemocks.MockStsGetCallerIdentityInvalidEndpointAccessDenied,
+	})
+	defer errorServer.Close()
+
+	_, err := sts.New(sess, &aws.Config{Endpoint: aws.String(errorServer.URL)}).GetCallerIdentityWithContext(ctx, &sts.GetCallerIdentityInput{})
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	var spans int
+	for _, span := range recorder.Ended() {
+		if span.InstrumentationScope().Name != tracerName {
+			continue
+		}
+		spans++
+
+		if a, e := span.Status().Code, codes.Error; a != e {
+			t.Errorf("%s: expected status %s, got %s", span.Name(), e, a)
+		}
+		if l := len(span.Events()); l != 1 {
+			t.Errorf("%s: expected 1 error event, got %d", span.Name(), l)
+		}
+		if a, e := spanAttribute(span, "aws.request_id").AsString(), mockRequestID; a != e {
+			t.Errorf("%s: expected request ID %q, got %q", span.Name(), e, a)
+		}
+	}
+	if spans != 2 { //nolint:mnd
+		t.Errorf("expected 2 spans, got %d", spans)
+	}
+}
+
+func spanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
@@ -0,0 +1,184 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/hashicorp/aws-sdk-go-base/v2/endpoints"
+)
+
+var (
+	accountIDRegex = regexp.MustCompile(`^\d{12}$`)
+	iamNameRegex   = regexp.MustCompile(`^[\w+=,.@-]+$`)
+	// IAM paths are either "/" or begin and end with "/", e.g. "/division_abc/subdivision_xyz/".
+	// See https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_identifiers.html#identifiers-arns.
+	iamPathRegex = regexp.MustCompile(`^/([\x21-\x7e]+/)?$`)
+)
+
+// InvalidARNError is returned when an ARN is not valid.
+type InvalidARNError struct {
+	arn    string
+	reason string
+}
+
+func (e *InvalidARNError) Error() string {
+	return fmt.Sprintf("invalid ARN (%s): %s", e.arn, e.reason)
+}
+
+// ARN returns the invalid ARN.
+func (e *InvalidARNError) ARN() string {
+	return e.arn
+}
+
+// Reason returns the reason the ARN is not valid.
+func (e *InvalidARNError) Reason() string {
+	return e.reason
+}
+
+func invalidARN(s, format string, a ...any) error {
+	return &InvalidARNError{
+		arn:    s,
+		reason: fmt.Sprintf(format, a...),
+	}
+}
+
+// awsManagedAccountID is the account ID in the ARNs of AWS managed resources, e.g. "arn:aws:iam::aws:policy/ReadOnlyAccess".
+const awsManagedAccountID = "aws"
+
+// ARN checks that the given string is a valid ARN in one of the known partitions.
+// If the ARN contains a Region, it must be in the ARN's partition.
+// If the ARN contains an account ID, it must be a 12-digit number or "aws" for AWS managed resources.
+func ARN(s string) (arn.ARN, error) {
+	v, err := arn.Parse(s)
+	if err != nil {
+		return arn.ARN{}, invalidARN(s, "%s", err)
+	}
+
+	var partition endpoints.Partition
+	var ok bool
+	for _, p := range endpoints.DefaultPartitions() {
+		if p.ID() == v.Partition {
+			partition, ok = p, true
+			break
+		}
+	}
+	if !ok {
+		return arn.ARN{}, invalidARN(s, "unknown partition %q", v.Partition)
+	}
+
+	if v.Service == "" {
+		return arn.ARN{}, invalidARN(s, "service not set")
+	}
+
+	if v.Region != "" {
+		if _, kind := endpoints.MatchPartitionForRegion([]endpoints.Partition{partition}, v.Region); kind == endpoints.PartitionMatchKindNone {
+			return arn.ARN{}, invalidARN(s, "Region %q is not in partition %q", v.Region, v.Partition)
+		}
+	}
+
+	if v.AccountID != "" && v.AccountID != awsManagedAccountID && !accountIDRegex.MatchString(v.AccountID) {
+		return arn.ARN{}, invalidARN(s, "account ID %q is not a 12-digit number", v.AccountID)
+	}
+
+	if v.Resource == "" {
+		return arn.ARN{}, invalidARN(s, "resource not set")
+	}
+
+	return v, nil
+}
+
+// IAMRoleARN checks that the given string is a valid IAM role ARN, e.g. "arn:aws:iam::123456789012:role/path/name".
+func IAMRoleARN(s string) error {
+	return iamPathNameARN(s, "role", false)
+}
+
+// IAMUserARN checks that the given string is a valid IAM user ARN, e.g. "arn:aws:iam::123456789012:user/path/name".
+func IAMUserARN(s string) error {
+	return iamPathNameARN(s, "user", false)
+}
+
+// IAMInstanceProfileARN checks that the given string is a valid IAM instance profile ARN,
+// e.g. "arn:aws:iam::123456789012:instance-profile/path/name".
+func IAMInstanceProfileARN(s string) error {
+	return iamPathNameARN(s, "instance-profile", false)
+}
+
+// IAMPolicyARN checks that the given string is a valid IAM managed policy ARN, e.g. "arn:aws:iam::123456789012:policy/path/name".
+// AWS managed policies have the account ID "aws", e.g. "arn:aws:iam::aws:policy/ReadOnlyAccess".
+func IAMPolicyARN(s string) error {
+	return iamPathNameARN(s, "policy", true)
+}
+
+// STSAssumedRoleARN checks that the given string is a valid STS assumed role ARN,
+// e.g. "arn:aws:sts::123456789012:assumed-role/name/session-name".
+func STSAssumedRoleARN(s string) error {
+	v, err := accountServiceARN(s, "sts", false)
+	if err != nil {
+		return err
+	}
+
+	resourceType, resource, _ := strings.Cut(v.Resource, "/")
+	if resourceType != "assumed-role" {
+		return invalidARN(s, "expected resource type %q, got %q", "assumed-role", resourceType)
+	}
+
+	roleName, sessionName, ok := strings.Cut(resource, "/")
+	if !ok || !iamNameRegex.MatchString(roleName) || !iamNameRegex.MatchString(sessionName) {
+		return invalidARN(s, "expected resource of the form %q", "assumed-role/{role-name}/{session-name}")
+	}
+
+	return nil
+}
+
+// accountServiceARN checks that the given string is a valid ARN for a global service's resource in an account.
+func accountServiceARN(s, service string, awsManaged bool) (arn.ARN, error) {
+	v, err := ARN(s)
+	if err != nil {
+		return arn.ARN{}, err
+	}
+
+	if v.Service != service {
+		return arn.ARN{}, invalidARN(s, "expected service %q, got %q", service, v.Service)
+	}
+	if v.Region != "" {
+		return arn.ARN{}, invalidARN(s, "Region must be empty for service %q", service)
+	}
+	if v.AccountID == "" {
+		return arn.ARN{}, invalidARN(s, "account ID not set")
+	}
+	if v.AccountID == awsManagedAccountID && !awsManaged {
+		return arn.ARN{}, invalidARN(s, "account ID %q is not a 12-digit number", v.AccountID)
+	}
+
+	return v, nil
+}
+
+// iamPathNameARN checks that the given string is a valid ARN for an IAM resource with a path and name,
+// i.e. "{resourceType}/{path}{name}".
+func iamPathNameARN(s, resourceType string, awsManaged bool) error {
+	v, err := accountServiceARN(s, "iam", awsManaged)
+	if err != nil {
+		return err
+	}
+
+	t, resource, _ := strings.Cut(v.Resource, "/")
+	if t != resourceType {
+		return invalidARN(s, "expected resource type %q, got %q", resourceType, t)
+	}
+
+	i := strings.LastIndex(resource, "/")
+	path, name := "/"+resource[:i+1], resource[i+1:]
+	if !iamPathRegex.MatchString(path) {
+		return invalidARN(s, "invalid path %q", path)
+	}
+	if !iamNameRegex.MatchString(name) {
+		return invalidARN(s, "invalid name %q", name)
+	}
+
+	return nil
+}
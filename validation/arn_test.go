@@ -0,0 +1,151 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validation
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestARN(t *testing.T) {
+	testcases := map[string]struct {
+		ExpectedReason string
+	}{
+		"arn:aws:s3:::bucket": {},
+		"arn:aws:dynamodb:us-east-1:123456789012:table/Table":      {},
+		"arn:aws-cn:ec2:cn-north-1:123456789012:instance/i-0123":   {},
+		"arn:aws:iam::aws:policy/ReadOnlyAccess":                   {},
+		"arn:aws:lambda:us-west-17:123456789012:function:Function": {},
+		"not-an-arn":                                           {ExpectedReason: "arn: invalid prefix"},
+		"arn:aws-example:s3:::bucket":                          {ExpectedReason: `unknown partition "aws-example"`},
+		"arn:aws::us-east-1:123456789012:resource":             {ExpectedReason: "service not set"},
+		"arn:aws:dynamodb:cn-north-1:123456789012:table/Table": {ExpectedReason: `Region "cn-north-1" is not in partition "aws"`},
+		"arn:aws:dynamodb:us-east-1:1234:table/Table":          {ExpectedReason: `account ID "1234" is not a 12-digit number`},
+		"arn:aws:dynamodb:us-east-1:123456789012:":             {ExpectedReason: "resource not set"},
+	}
+
+	for s, testcase := range testcases {
+		s, testcase := s, testcase
+
+		t.Run(s, func(t *testing.T) {
+			_, err := ARN(s)
+
+			checkInvalidARNError(t, s, err, testcase.ExpectedReason)
+		})
+	}
+}
+
+func TestIAMARNs(t *testing.T) {
+	testcases := map[string]struct {
+		Validate       func(string) error
+		ARN            string
+		ExpectedReason string
+	}{
+		"role": {
+			Validate: IAMRoleARN,
+			ARN:      "arn:aws:iam::123456789012:role/Role",
+		},
+		"role with path": {
+			Validate: IAMRoleARN,
+			ARN:      "arn:aws-us-gov:iam::123456789012:role/division/team/Role",
+		},
+		"role wrong service": {
+			Validate:       IAMRoleARN,
+			ARN:            "arn:aws:sts::123456789012:role/Role",
+			ExpectedReason: `expected service "iam", got "sts"`,
+		},
+		"role with Region": {
+			Validate:       IAMRoleARN,
+			ARN:            "arn:aws:iam:us-east-1:123456789012:role/Role",
+			ExpectedReason: `Region must be empty for service "iam"`,
+		},
+		"role without account ID": {
+			Validate:       IAMRoleARN,
+			ARN:            "arn:aws:iam:::role/Role",
+			ExpectedReason: "account ID not set",
+		},
+		"role with AWS managed account ID": {
+			Validate:       IAMRoleARN,
+			ARN:            "arn:aws:iam::aws:role/Role",
+			ExpectedReason: `account ID "aws" is not a 12-digit number`,
+		},
+		"role without name": {
+			Validate:       IAMRoleARN,
+			ARN:            "arn:aws:iam::123456789012:role/path/",
+			ExpectedReason: `invalid name ""`,
+		},
+		"role invalid name": {
+			Validate:       IAMRoleARN,
+			ARN:            "arn:aws:iam::123456789012:role/Role*",
+			ExpectedReason: `invalid name "Role*"`,
+		},
+		"role wrong resource type": {
+			Validate:       IAMRoleARN,
+			ARN:            "arn:aws:iam::123456789012:policy/Policy",
+			ExpectedReason: `expected resource type "role", got "policy"`,
+		},
+		"policy": {
+			Validate: IAMPolicyARN,
+			ARN:      "arn:aws:iam::123456789012:policy/path/Policy",
+		},
+		"AWS managed policy": {
+			Validate: IAMPolicyARN,
+			ARN:      "arn:aws:iam::aws:policy/service-role/AWSLambdaBasicExecutionRole",
+		},
+		"user": {
+			Validate: IAMUserARN,
+			ARN:      "arn:aws:iam::123456789012:user/User",
+		},
+		"instance profile": {
+			Validate: IAMInstanceProfileARN,
+			ARN:      "arn:aws:iam::123456789012:instance-profile/Profile",
+		},
+		"assumed role": {
+			Validate: STSAssumedRoleARN,
+			ARN:      "arn:aws:sts::123456789012:assumed-role/Role/session@example.com",
+		},
+		"assumed role without session name": {
+			Validate:       STSAssumedRoleARN,
+			ARN:            "arn:aws:sts::123456789012:assumed-role/Role",
+			ExpectedReason: `expected resource of the form "assumed-role/{role-name}/{session-name}"`,
+		},
+		"assumed role wrong resource type": {
+			Validate:       STSAssumedRoleARN,
+			ARN:            "arn:aws:sts::123456789012:federated-user/User",
+			ExpectedReason: `expected resource type "assumed-role", got "federated-user"`,
+		},
+	}
+
+	for name, testcase := range testcases {
+		testcase := testcase
+
+		t.Run(name, func(t *testing.T) {
+			err := testcase.Validate(testcase.ARN)
+
+			checkInvalidARNError(t, testcase.ARN, err, testcase.ExpectedReason)
+		})
+	}
+}
+
+func checkInvalidARNError(t *testing.T, s string, err error, expectedReason string) {
+	t.Helper()
+
+	if expectedReason == "" {
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+		return
+	}
+
+	var arnErr *InvalidARNError
+	if !errors.As(err, &arnErr) {
+		t.Fatalf("expected InvalidARNError, got %v", err)
+	}
+	if a, e := arnErr.ARN(), s; a != e {
+		t.Errorf("expected ARN %q, got %q", e, a)
+	}
+	if a, e := arnErr.Reason(), expectedReason; a != e {
+		t.Errorf("expected reason %q, got %q", e, a)
+	}
+}
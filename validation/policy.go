@@ -0,0 +1,494 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validation
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// maxSessionPolicyLength is the maximum length of a session policy, ignoring whitespace.
+// See https://docs.aws.amazon.com/STS/latest/APIReference/API_AssumeRole.html.
+const maxSessionPolicyLength = 2048
+
+// PolicyError is returned when an IAM policy document is not valid.
+type PolicyError struct {
+	path    string
+	line    int
+	column  int
+	message string
+}
+
+func (e *PolicyError) Error() string {
+	if e.path == "" {
+		return fmt.Sprintf("line %d, column %d: %s", e.line, e.column, e.message)
+	}
+	return fmt.Sprintf("line %d, column %d: %s: %s", e.line, e.column, e.path, e.message)
+}
+
+// Path returns the path of the invalid element in the policy document, e.g. "Statement[0].Effect".
+func (e *PolicyError) Path() string {
+	return e.path
+}
+
+// Line returns the 1-based line number of the invalid element.
+func (e *PolicyError) Line() int {
+	return e.line
+}
+
+// Column returns the 1-based column number, in bytes, of the invalid element.
+func (e *PolicyError) Column() int {
+	return e.column
+}
+
+// IAMPolicyDocument checks that the given string is a valid IAM policy document.
+// The structure of the document, its version, and the shape of each statement's elements and condition operators are checked.
+// See https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_policies_grammar.html.
+//
+// Any errors are returned as *PolicyError values, joined using errors.Join.
+func IAMPolicyDocument(s string) error {
+	root, err := parsePolicyJSON(s)
+	if err != nil {
+		return err
+	}
+
+	v := policyValidator{src: s}
+	v.document(root)
+
+	return errors.Join(v.errs...)
+}
+
+// SessionPolicy checks that the given string is a valid IAM policy document for use as an inline session policy,
+// which is limited to 2048 characters, ignoring whitespace.
+// An empty policy document, "{}", is also accepted.
+func SessionPolicy(s string) error {
+	if root, err := parsePolicyJSON(s); err == nil && root.kind == policyNodeObject && len(root.members) == 0 {
+		return nil
+	}
+
+	if err := IAMPolicyDocument(s); err != nil {
+		return err
+	}
+
+	var b bytes.Buffer
+	if err := json.Compact(&b, []byte(s)); err != nil {
+		return err
+	}
+	if n := b.Len(); n > maxSessionPolicyLength {
+		return &PolicyError{
+			line:    1,
+			column:  1,
+			message: fmt.Sprintf("session policy length (%d) exceeds maximum (%d), ignoring whitespace", n, maxSessionPolicyLength),
+		}
+	}
+
+	return nil
+}
+
+type policyNodeKind int
+
+const (
+	policyNodeObject policyNodeKind = iota
+	policyNodeArray
+	policyNodeString
+	policyNodeOther // Number, boolean or null.
+)
+
+// policyNode is a JSON value and its offset in the source document.
+type policyNode struct {
+	kind    policyNodeKind
+	offset  int
+	members []policyMember // Object members in document order.
+	items   []*policyNode  // Array items.
+	str     string
+}
+
+type policyMember struct {
+	key       string
+	keyOffset int
+	value     *policyNode
+}
+
+// parsePolicyJSON parses a JSON document, recording the offset of each value.
+func parsePolicyJSON(s string) (*policyNode, error) {
+	p := policyParser{
+		src: s,
+		dec: json.NewDecoder(strings.NewReader(s)),
+	}
+	p.dec.UseNumber()
+
+	root, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := p.dec.Token(); err != io.EOF { //nolint:errorlint
+		return nil, newPolicyError(s, int(p.dec.InputOffset()), "", "unexpected data after policy document")
+	}
+
+	return root, nil
+}
+
+type policyParser struct {
+	src string
+	dec *json.Decoder
+}
+
+// token returns the next token and its offset in the source document.
+func (p *policyParser) token() (json.Token, int, error) {
+	// InputOffset is the end of the previous token; skip any separators and whitespace to the start of this one.
+	offset := int(p.dec.InputOffset())
+	for offset < len(p.src) && strings.IndexByte(" \t\r\n,:", p.src[offset]) >= 0 {
+		offset++
+	}
+
+	t, err := p.dec.Token()
+	if err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			// The offset is the number of bytes read, including the invalid character.
+			return nil, 0, newPolicyError(p.src, max(int(syntaxErr.Offset)-1, 0), "", syntaxErr.Error())
+		}
+		if err == io.EOF { //nolint:errorlint
+			return nil, 0, newPolicyError(p.src, len(p.src), "", "unexpected end of policy document")
+		}
+		return nil, 0, newPolicyError(p.src, offset, "", err.Error())
+	}
+
+	return t, offset, nil
+}
+
+func (p *policyParser) value() (*policyNode, error) {
+	t, offset, err := p.token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := t.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			n := &policyNode{kind: policyNodeObject, offset: offset}
+			for p.dec.More() {
+				t, keyOffset, err := p.token()
+				if err != nil {
+					return nil, err
+				}
+				value, err := p.value()
+				if err != nil {
+					return nil, err
+				}
+				n.members = append(n.members, policyMember{key: t.(string), keyOffset: keyOffset, value: value}) //nolint:forcetypeassert
+			}
+			if _, _, err := p.token(); err != nil { // '}'
+				return nil, err
+			}
+			return n, nil
+
+		case '[':
+			n := &policyNode{kind: policyNodeArray, offset: offset}
+			for p.dec.More() {
+				value, err := p.value()
+				if err != nil {
+					return nil, err
+				}
+				n.items = append(n.items, value)
+			}
+			if _, _, err := p.token(); err != nil { // ']'
+				return nil, err
+			}
+			return n, nil
+		}
+
+	case string:
+		return &policyNode{kind: policyNodeString, offset: offset, str: t}, nil
+	}
+
+	return &policyNode{kind: policyNodeOther, offset: offset}, nil
+}
+
+func newPolicyError(src string, offset int, path, message string) *PolicyError {
+	offset = min(offset, len(src))
+	line := strings.Count(src[:offset], "\n") + 1
+	column := offset - strings.LastIndexByte(src[:offset], '\n')
+
+	return &PolicyError{
+		path:    path,
+		line:    line,
+		column:  column,
+		message: message,
+	}
+}
+
+var (
+	policyVersions = []string{"2012-10-17", "2008-10-17"}
+
+	policyElements    = []string{"Version", "Id", "Statement"}
+	statementElements = []string{"Sid", "Effect", "Principal", "NotPrincipal", "Action", "NotAction", "Resource", "NotResource", "Condition"}
+	principalTypes    = []string{"AWS", "CanonicalUser", "Federated", "Service"}
+
+	// See https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_policies_elements_condition_operators.html.
+	conditionOperators = []string{
+		"StringEquals", "StringNotEquals", "StringEqualsIgnoreCase", "StringNotEqualsIgnoreCase", "StringLike", "StringNotLike",
+		"NumericEquals", "NumericNotEquals", "NumericLessThan", "NumericLessThanEquals", "NumericGreaterThan", "NumericGreaterThanEquals",
+		"DateEquals", "DateNotEquals", "DateLessThan", "DateLessThanEquals", "DateGreaterThan", "DateGreaterThanEquals",
+		"Bool",
+		"BinaryEquals",
+		"IpAddress", "NotIpAddress",
+		"ArnEquals", "ArnLike", "ArnNotEquals", "ArnNotLike",
+		"Null",
+	}
+)
+
+type policyValidator struct {
+	src  string
+	errs []error
+}
+
+func (v *policyValidator) errorf(n *policyNode, path, format string, a ...any) {
+	v.errs = append(v.errs, newPolicyError(v.src, n.offset, path, fmt.Sprintf(format, a...)))
+}
+
+// members returns an object's members indexed by key, reporting unknown and duplicate keys.
+func (v *policyValidator) members(n *policyNode, path string, allowed []string) map[string]*policyNode {
+	m := make(map[string]*policyNode, len(n.members))
+
+	for _, member := range n.members {
+		memberPath := joinPolicyPath(path, member.key)
+		keyNode := &policyNode{offset: member.keyOffset}
+
+		if allowed != nil && !slices.Contains(allowed, member.key) {
+			v.errorf(keyNode, memberPath, "unknown element")
+			continue
+		}
+		if _, ok := m[member.key]; ok {
+			v.errorf(keyNode, memberPath, "duplicate element")
+			continue
+		}
+		m[member.key] = member.value
+	}
+
+	return m
+}
+
+func (v *policyValidator) document(n *policyNode) {
+	if n.kind != policyNodeObject {
+		v.errorf(n, "", "policy document must be a JSON object")
+		return
+	}
+
+	m := v.members(n, "", policyElements)
+
+	if version, ok := m["Version"]; ok {
+		if version.kind != policyNodeString || !slices.Contains(policyVersions, version.str) {
+			v.errorf(version, "Version", "must be one of %s", strings.Join(policyVersions, ", "))
+		}
+	}
+
+	if id, ok := m["Id"]; ok && id.kind != policyNodeString {
+		v.errorf(id, "Id", "must be a string")
+	}
+
+	statement, ok := m["Statement"]
+	if !ok {
+		v.errorf(n, "", "Statement is required")
+		return
+	}
+
+	switch statement.kind {
+	case policyNodeObject:
+		v.statement(statement, "Statement")
+	case policyNodeArray:
+		if len(statement.items) == 0 {
+			v.errorf(statement, "Statement", "must not be empty")
+		}
+		for i, item := range statement.items {
+			v.statement(item, fmt.Sprintf("Statement[%d]", i))
+		}
+	default:
+		v.errorf(statement, "Statement", "must be an object or a list of objects")
+	}
+}
+
+func (v *policyValidator) statement(n *policyNode, path string) {
+	if n.kind != policyNodeObject {
+		v.errorf(n, path, "must be an object")
+		return
+	}
+
+	m := v.members(n, path, statementElements)
+
+	if sid, ok := m["Sid"]; ok && sid.kind != policyNodeString {
+		v.errorf(sid, joinPolicyPath(path, "Sid"), "must be a string")
+	}
+
+	if effect, ok := m["Effect"]; !ok {
+		v.errorf(n, path, "Effect is required")
+	} else if effect.kind != policyNodeString || (effect.str != "Allow" && effect.str != "Deny") {
+		v.errorf(effect, joinPolicyPath(path, "Effect"), `must be "Allow" or "Deny"`)
+	}
+
+	v.exclusive(n, m, path, "Principal", "NotPrincipal", false)
+	for _, key := range []string{"Principal", "NotPrincipal"} {
+		if principal, ok := m[key]; ok {
+			v.principal(principal, joinPolicyPath(path, key))
+		}
+	}
+
+	v.exclusive(n, m, path, "Action", "NotAction", true)
+	for _, key := range []string{"Action", "NotAction"} {
+		if action, ok := m[key]; ok {
+			v.stringOrList(action, joinPolicyPath(path, key), validAction)
+		}
+	}
+
+	v.exclusive(n, m, path, "Resource", "NotResource", false)
+	for _, key := range []string{"Resource", "NotResource"} {
+		if resource, ok := m[key]; ok {
+			v.stringOrList(resource, joinPolicyPath(path, key), nil)
+		}
+	}
+
+	if condition, ok := m["Condition"]; ok {
+		v.condition(condition, joinPolicyPath(path, "Condition"))
+	}
+}
+
+// exclusive checks that at most one, or exactly one if required, of a pair of elements is set.
+func (v *policyValidator) exclusive(n *policyNode, m map[string]*policyNode, path, a, b string, required bool) {
+	_, okA := m[a]
+	_, okB := m[b]
+
+	switch {
+	case okA && okB:
+		v.errorf(m[b], joinPolicyPath(path, b), "cannot be set with %s", a)
+	case required && !okA && !okB:
+		v.errorf(n, path, "one of %s or %s is required", a, b)
+	}
+}
+
+func (v *policyValidator) principal(n *policyNode, path string) {
+	switch n.kind {
+	case policyNodeString:
+		if n.str != "*" {
+			v.errorf(n, path, `must be "*" or an object`)
+		}
+	case policyNodeObject:
+		m := v.members(n, path, principalTypes)
+		for _, key := range principalTypes {
+			if value, ok := m[key]; ok {
+				v.stringOrList(value, joinPolicyPath(path, key), nil)
+			}
+		}
+	default:
+		v.errorf(n, path, `must be "*" or an object`)
+	}
+}
+
+// stringOrList checks that an element is a string or a non-empty list of strings, optionally validating each string.
+func (v *policyValidator) stringOrList(n *policyNode, path string, validate func(string) error) {
+	check := func(n *policyNode, path string) {
+		if n.kind != policyNodeString {
+			v.errorf(n, path, "must be a string")
+			return
+		}
+		if validate != nil {
+			if err := validate(n.str); err != nil {
+				v.errorf(n, path, "%s", err)
+			}
+		}
+	}
+
+	switch n.kind {
+	case policyNodeString:
+		check(n, path)
+	case policyNodeArray:
+		if len(n.items) == 0 {
+			v.errorf(n, path, "must not be empty")
+		}
+		for i, item := range n.items {
+			check(item, fmt.Sprintf("%s[%d]", path, i))
+		}
+	default:
+		v.errorf(n, path, "must be a string or a list of strings")
+	}
+}
+
+func (v *policyValidator) condition(n *policyNode, path string) {
+	if n.kind != policyNodeObject {
+		v.errorf(n, path, "must be an object")
+		return
+	}
+
+	for _, member := range n.members {
+		operatorPath := joinPolicyPath(path, member.key)
+
+		if !validConditionOperator(member.key) {
+			v.errorf(&policyNode{offset: member.keyOffset}, operatorPath, "unknown condition operator")
+			continue
+		}
+
+		if member.value.kind != policyNodeObject {
+			v.errorf(member.value, operatorPath, "must be an object")
+			continue
+		}
+
+		for _, key := range member.value.members {
+			keyPath := joinPolicyPath(operatorPath, key.key)
+			switch key.value.kind {
+			case policyNodeString, policyNodeOther:
+			case policyNodeArray:
+				for i, item := range key.value.items {
+					if item.kind != policyNodeString && item.kind != policyNodeOther {
+						v.errorf(item, fmt.Sprintf("%s[%d]", keyPath, i), "must be a scalar value")
+					}
+				}
+			default:
+				v.errorf(key.value, keyPath, "must be a scalar value or a list of scalar values")
+			}
+		}
+	}
+}
+
+// validAction checks that an action has the form "{service}:{action}", where the action can include wildcards, or is "*".
+func validAction(s string) error {
+	if s == "*" {
+		return nil
+	}
+
+	service, action, ok := strings.Cut(s, ":")
+	if !ok || service == "" || action == "" || strings.ContainsAny(service, "*?") {
+		return fmt.Errorf(`action %q must have the form "{service}:{action}"`, s)
+	}
+
+	return nil
+}
+
+// validConditionOperator checks a condition operator, including any "ForAllValues:" or "ForAnyValue:" prefix and "IfExists" suffix.
+func validConditionOperator(s string) bool {
+	for _, prefix := range []string{"ForAllValues:", "ForAnyValue:"} {
+		if v, ok := strings.CutPrefix(s, prefix); ok {
+			s = v
+			break
+		}
+	}
+
+	if v, ok := strings.CutSuffix(s, "IfExists"); ok && v != "Null" {
+		s = v
+	}
+
+	return slices.Contains(conditionOperators, s)
+}
+
+func joinPolicyPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
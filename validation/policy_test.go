@@ -0,0 +1,208 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validation
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestIAMPolicyDocument(t *testing.T) {
+	testcases := map[string]struct {
+		Policy         string
+		ExpectedErrors []string
+	}{
+		"valid": {
+			Policy: `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "ReadOnly",
+      "Effect": "Allow",
+      "Action": ["s3:Get*", "s3:List*"],
+      "Resource": "*",
+      "Condition": {
+        "StringEquals": {"aws:RequestedRegion": ["us-east-1", "us-west-2"]},
+        "ForAnyValue:StringLikeIfExists": {"aws:TagKeys": "team-*"},
+        "Bool": {"aws:SecureTransport": true},
+        "NumericLessThan": {"s3:max-keys": 10}
+      }
+    },
+    {
+      "Effect": "Deny",
+      "NotAction": "iam:*",
+      "NotResource": ["arn:aws:s3:::bucket", "arn:aws:s3:::bucket/*"]
+    }
+  ]
+}`,
+		},
+		"valid single statement": {
+			Policy: `{"Statement": {"Effect": "Allow", "Action": "*", "Resource": "*"}}`,
+		},
+		"valid trust policy": {
+			Policy: `{
+  "Version": "2012-10-17",
+  "Statement": [{
+    "Effect": "Allow",
+    "Principal": {"Service": "ec2.amazonaws.com", "AWS": ["arn:aws:iam::123456789012:root"]},
+    "Action": "sts:AssumeRole"
+  }]
+}`,
+		},
+		"syntax error": {
+			Policy: "{\n  \"Statement\": [\n    {\"Effect\": \"Allow\",}\n  ]\n}",
+			ExpectedErrors: []string{
+				"line 3, column 23: invalid character ',' looking for beginning of value",
+			},
+		},
+		"unexpected end": {
+			Policy: `{"Statement": [`,
+			ExpectedErrors: []string{
+				"line 1, column 15: unexpected end of JSON input",
+			},
+		},
+		"empty": {
+			Policy: ``,
+			ExpectedErrors: []string{
+				"line 1, column 1: unexpected end of policy document",
+			},
+		},
+		"not an object": {
+			Policy: `[]`,
+			ExpectedErrors: []string{
+				"line 1, column 1: policy document must be a JSON object",
+			},
+		},
+		"missing statement": {
+			Policy: `{}`,
+			ExpectedErrors: []string{
+				"line 1, column 1: Statement is required",
+			},
+		},
+		"invalid version and unknown element": {
+			Policy: "{\n  \"Version\": \"2012-10-18\",\n  \"Statment\": [],\n  \"Statement\": []\n}",
+			ExpectedErrors: []string{
+				`line 3, column 3: Statment: unknown element`,
+				`line 2, column 14: Version: must be one of 2012-10-17, 2008-10-17`,
+				`line 4, column 16: Statement: must not be empty`,
+			},
+		},
+		"invalid statement": {
+			Policy: `{
+  "Statement": [
+    {
+      "Effect": "allow",
+      "Action": ["s3:GetObject", 1, "GetObject"],
+      "NotAction": "s3:*",
+      "Resource": [],
+      "Condition": {
+        "StringEqualz": {"aws:username": "alice"},
+        "NullIfExists": {"aws:TokenIssueTime": "true"},
+        "StringEquals": {"aws:username": {"name": "alice"}}
+      }
+    },
+    "statement",
+    {
+      "Sid": "NoEffect",
+      "Resource": "*"
+    }
+  ]
+}`,
+			ExpectedErrors: []string{
+				`line 4, column 17: Statement[0].Effect: must be "Allow" or "Deny"`,
+				`line 6, column 20: Statement[0].NotAction: cannot be set with Action`,
+				`line 5, column 34: Statement[0].Action[1]: must be a string`,
+				`line 5, column 37: Statement[0].Action[2]: action "GetObject" must have the form "{service}:{action}"`,
+				`line 7, column 19: Statement[0].Resource: must not be empty`,
+				`line 9, column 9: Statement[0].Condition.StringEqualz: unknown condition operator`,
+				`line 10, column 9: Statement[0].Condition.NullIfExists: unknown condition operator`,
+				`line 11, column 42: Statement[0].Condition.StringEquals.aws:username: must be a scalar value or a list of scalar values`,
+				`line 14, column 5: Statement[1]: must be an object`,
+				`line 15, column 5: Statement[2]: Effect is required`,
+				`line 15, column 5: Statement[2]: one of Action or NotAction is required`,
+			},
+		},
+		"invalid principal": {
+			Policy: `{"Statement": {"Effect": "Allow", "Action": "*", "Principal": {"User": "alice", "AWS": 1}}}`,
+			ExpectedErrors: []string{
+				`line 1, column 64: Statement.Principal.User: unknown element`,
+				`line 1, column 88: Statement.Principal.AWS: must be a string or a list of strings`,
+			},
+		},
+		"duplicate element": {
+			Policy: `{"Statement": {"Effect": "Allow", "Effect": "Deny", "Action": "*"}}`,
+			ExpectedErrors: []string{
+				`line 1, column 35: Statement.Effect: duplicate element`,
+			},
+		},
+	}
+
+	for name, testcase := range testcases {
+		testcase := testcase
+
+		t.Run(name, func(t *testing.T) {
+			err := IAMPolicyDocument(testcase.Policy)
+
+			if diff := cmp.Diff(policyErrorStrings(t, err), testcase.ExpectedErrors); diff != "" {
+				t.Errorf("unexpected errors difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestSessionPolicy(t *testing.T) {
+	statement := `{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::bucket/key"}`
+
+	policy := func(n int) string {
+		return `{"Version": "2012-10-17", "Statement": [` + strings.Repeat(statement+", ", n-1) + statement + `]}`
+	}
+
+	if err := SessionPolicy(policy(1)); err != nil {
+		t.Errorf("expected no error, got %s", err)
+	}
+
+	// An empty policy document is accepted.
+	if err := SessionPolicy(" {\n} "); err != nil {
+		t.Errorf("expected no error, got %s", err)
+	}
+
+	// Whitespace is not counted.
+	if err := SessionPolicy(strings.ReplaceAll(policy(25), ", ", ",\n    ")); err != nil {
+		t.Errorf("expected no error, got %s", err)
+	}
+
+	err := SessionPolicy(policy(50))
+	if diff := cmp.Diff(policyErrorStrings(t, err), []string{
+		"line 1, column 1: session policy length (4038) exceeds maximum (2048), ignoring whitespace",
+	}); diff != "" {
+		t.Errorf("unexpected errors difference: %s", diff)
+	}
+}
+
+func policyErrorStrings(t *testing.T, err error) []string {
+	t.Helper()
+
+	if err == nil {
+		return nil
+	}
+
+	errs := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok { //nolint:errorlint
+		errs = joined.Unwrap()
+	}
+
+	var s []string
+	for _, err := range errs {
+		var policyErr *PolicyError
+		if !errors.As(err, &policyErr) {
+			t.Fatalf("expected PolicyError, got %T: %s", err, err)
+		}
+		s = append(s, policyErr.Error())
+	}
+
+	return s
+}
@@ -5,21 +5,54 @@ package validation
 
 import (
 	"fmt"
+	"math"
 	"slices"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/aws-sdk-go-base/v2/endpoints"
 )
 
+// maxSuggestionDistance is the maximum edit distance between an invalid Region and a suggested Region.
+const maxSuggestionDistance = 2
+
 type InvalidRegionError struct {
-	region string
+	region           string
+	suggestedRegions []string
 }
 
 func (e *InvalidRegionError) Error() string {
-	return fmt.Sprintf("invalid AWS Region: %s", e.region)
+	msg := fmt.Sprintf("invalid AWS Region: %s", e.region)
+
+	switch len(e.suggestedRegions) {
+	case 0:
+	case 1:
+		msg += fmt.Sprintf(". Did you mean %s?", e.suggestedRegions[0])
+	default:
+		msg += fmt.Sprintf(". Did you mean one of %s?", strings.Join(e.suggestedRegions, ", "))
+	}
+
+	return msg
+}
+
+// Region returns the invalid Region.
+func (e *InvalidRegionError) Region() string {
+	return e.region
+}
+
+// SuggestedRegions returns the valid Regions closest to the invalid Region.
+func (e *InvalidRegionError) SuggestedRegions() []string {
+	return e.suggestedRegions
 }
 
 // SupportedRegion checks if the given region is a valid AWS region.
+// If not, the returned *InvalidRegionError includes any similar valid Regions.
+// An error is also returned if the partitions named in the environment cannot be loaded, see endpoints.PartitionsFileEnvVar.
 func SupportedRegion(region string) error {
+	if err := endpoints.LoadEnvironmentPartitions(); err != nil {
+		return fmt.Errorf("loading partitions: %w", err)
+	}
+
 	if slices.ContainsFunc(endpoints.DefaultPartitions(), func(p endpoints.Partition) bool {
 		_, ok := p.Regions()[region]
 		return ok
@@ -28,6 +61,88 @@ func SupportedRegion(region string) error {
 	}
 
 	return &InvalidRegionError{
-		region: region,
+		region:           region,
+		suggestedRegions: similarRegions(region),
+	}
+}
+
+// similarRegions returns the Regions in all partitions which are most similar to the given Region.
+// A Region is similar if it starts with the given Region or, if no Region does, is within a small edit distance of it, ignoring case.
+func similarRegions(region string) []string {
+	region = strings.ToLower(strings.TrimSpace(region))
+	if region == "" {
+		return nil
+	}
+
+	// Prefix matches are ranked by the number of characters added, which is not limited, e.g. "us-gov" for "us-gov-west-1".
+	prefixDistances := make(map[string]int)
+	editDistances := make(map[string]int)
+	for _, p := range endpoints.DefaultPartitions() {
+		for id := range p.Regions() {
+			if strings.HasPrefix(id, region) {
+				prefixDistances[id] = len(id) - len(region)
+				continue
+			}
+			if d := editDistance(region, id); d <= maxSuggestionDistance {
+				editDistances[id] = d
+			}
+		}
+	}
+
+	if len(prefixDistances) > 0 {
+		return closestRegions(prefixDistances)
+	}
+
+	return closestRegions(editDistances)
+}
+
+// closestRegions returns the Regions with the smallest distance, in sorted order.
+func closestRegions(distances map[string]int) []string {
+	closest := math.MaxInt
+	for _, d := range distances {
+		closest = min(closest, d)
+	}
+
+	var regions []string
+	for id, d := range distances {
+		if d == closest {
+			regions = append(regions, id)
+		}
+	}
+	sort.Strings(regions)
+
+	if len(regions) > maxSuggestedRegions {
+		regions = regions[:maxSuggestedRegions]
 	}
+
+	return regions
+}
+
+// editDistance returns the optimal string alignment distance between two strings,
+// i.e. the number of insertions, deletions, substitutions and transpositions of adjacent characters needed to transform one into the other.
+func editDistance(a, b string) int {
+	// d[i][j] is the distance between the first i characters of a and the first j characters of b.
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return d[len(a)][len(b)]
 }
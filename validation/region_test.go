@@ -4,7 +4,10 @@
 package validation
 
 import (
+	"errors"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestSupportedRegion(t *testing.T) {
@@ -48,3 +51,81 @@ func TestSupportedRegion(t *testing.T) {
 		})
 	}
 }
+
+func TestSupportedRegionSuggestions(t *testing.T) {
+	testcases := map[string]struct {
+		ExpectedSuggestions []string
+		ExpectedMessage     string
+	}{
+		"us-est-1": {
+			ExpectedSuggestions: []string{"us-east-1", "us-west-1"},
+			ExpectedMessage:     "invalid AWS Region: us-est-1. Did you mean one of us-east-1, us-west-1?",
+		},
+		"eu-wset-2": {
+			ExpectedSuggestions: []string{"eu-west-2"},
+			ExpectedMessage:     "invalid AWS Region: eu-wset-2. Did you mean eu-west-2?",
+		},
+		"US-EAST-1": {
+			ExpectedSuggestions: []string{"us-east-1"},
+			ExpectedMessage:     "invalid AWS Region: US-EAST-1. Did you mean us-east-1?",
+		},
+		"us-east": {
+			ExpectedSuggestions: []string{"us-east-1", "us-east-2"},
+			ExpectedMessage:     "invalid AWS Region: us-east. Did you mean one of us-east-1, us-east-2?",
+		},
+		"us-gov": {
+			ExpectedSuggestions: []string{"us-gov-east-1", "us-gov-west-1"},
+			ExpectedMessage:     "invalid AWS Region: us-gov. Did you mean one of us-gov-east-1, us-gov-west-1?",
+		},
+		"us-gov-wst-1": {
+			ExpectedSuggestions: []string{"us-gov-west-1"},
+			ExpectedMessage:     "invalid AWS Region: us-gov-wst-1. Did you mean us-gov-west-1?",
+		},
+		"invalid": {
+			ExpectedMessage: "invalid AWS Region: invalid",
+		},
+		"": {
+			ExpectedMessage: "invalid AWS Region: ",
+		},
+	}
+
+	for region, testcase := range testcases {
+		region, testcase := region, testcase
+
+		t.Run(region, func(t *testing.T) {
+			err := SupportedRegion(region)
+
+			var regionErr *InvalidRegionError
+			if !errors.As(err, &regionErr) {
+				t.Fatalf("expected InvalidRegionError, got %v", err)
+			}
+
+			if diff := cmp.Diff(regionErr.SuggestedRegions(), testcase.ExpectedSuggestions); diff != "" {
+				t.Errorf("unexpected suggested Regions difference: %s", diff)
+			}
+			if a, e := regionErr.Error(), testcase.ExpectedMessage; a != e {
+				t.Errorf("expected message %q, got %q", e, a)
+			}
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	testcases := []struct {
+		A, B     string
+		Expected int
+	}{
+		{"", "", 0},
+		{"us-east-1", "us-east-1", 0},
+		{"us-est-1", "us-east-1", 1},
+		{"us-eats-1", "us-east-1", 1},
+		{"us-east-1", "us-west-2", 3},
+		{"", "abc", 3},
+	}
+
+	for _, testcase := range testcases {
+		if a, e := editDistance(testcase.A, testcase.B), testcase.Expected; a != e {
+			t.Errorf("expected edit distance %d between %q and %q, got %d", e, testcase.A, testcase.B, a)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validation
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/aws-sdk-go-base/v2/diag"
+	"github.com/hashicorp/aws-sdk-go-base/v2/endpoints"
+)
+
+const maxSuggestedRegions = 3
+
+// ServiceNotAvailableError is a diagnostic for a service which is not available in a Region.
+type ServiceNotAvailableError struct {
+	serviceID        string
+	region           string
+	partition        string
+	suggestedRegions []string
+}
+
+func (e ServiceNotAvailableError) Error() string {
+	return fmt.Sprintf("service %s is not available in AWS Region %s", e.serviceID, e.region)
+}
+
+func (e ServiceNotAvailableError) Severity() diag.Severity {
+	return diag.SeverityError
+}
+
+func (e ServiceNotAvailableError) Summary() string {
+	return "Service Not Available in Region"
+}
+
+func (e ServiceNotAvailableError) Detail() string {
+	detail := fmt.Sprintf("The service %q is not available in the AWS Region %q in partition %q.", e.serviceID, e.region, e.partition)
+	if len(e.suggestedRegions) > 0 {
+		detail += fmt.Sprintf("\n\nThe service is available in these nearby Regions: %s", strings.Join(e.suggestedRegions, ", "))
+	}
+	return detail
+}
+
+func (e ServiceNotAvailableError) Equal(other diag.Diagnostic) bool {
+	ed, ok := other.(ServiceNotAvailableError)
+	if !ok {
+		return false
+	}
+
+	return ed.Summary() == e.Summary() && ed.Detail() == e.Detail()
+}
+
+func (e ServiceNotAvailableError) Err() error {
+	return e
+}
+
+// ServiceID returns the ID of the unavailable service.
+func (e ServiceNotAvailableError) ServiceID() string {
+	return e.serviceID
+}
+
+// Region returns the Region in which the service is not available.
+func (e ServiceNotAvailableError) Region() string {
+	return e.region
+}
+
+// SuggestedRegions returns the nearest Regions in the same partition in which the service is available.
+func (e ServiceNotAvailableError) SuggestedRegions() []string {
+	return e.suggestedRegions
+}
+
+var _ diag.DiagnosticWithErr = ServiceNotAvailableError{}
+
+// SupportedServices checks that each of the given service endpoint IDs, e.g. "dynamodb", is available in the given Region.
+// A diagnostic is returned for each unavailable service.
+// No diagnostic is returned if availability is unknown, e.g. because the Region was launched after the endpoint data was last updated.
+func SupportedServices(region string, serviceIDs ...string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if err := SupportedRegion(region); err != nil {
+		return diags.AddSimpleError(err)
+	}
+
+	partition, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region)
+	if !ok {
+		return diags.AddSimpleError(&InvalidRegionError{
+			region: region,
+		})
+	}
+
+	for _, serviceID := range serviceIDs {
+		if available, ok := partition.IsServiceAvailableIn(serviceID, region); available || !ok {
+			continue
+		}
+
+		diags = diags.Append(ServiceNotAvailableError{
+			serviceID:        serviceID,
+			region:           region,
+			partition:        partition.ID(),
+			suggestedRegions: nearestRegions(partition, serviceID, region),
+		})
+	}
+
+	return diags
+}
+
+// nearestRegions returns the Regions in the partition in which the service is available, nearest first.
+// Nearness is estimated from the Regions' geographies and IDs, e.g. "eu-west-2" is nearer to "eu-west-1" than "eu-central-1" is.
+func nearestRegions(partition endpoints.Partition, serviceID, regionID string) []string {
+	partitionRegions := partition.Regions()
+	region := partitionRegions[regionID]
+
+	var regions []endpoints.Region
+	for id, r := range partitionRegions {
+		if available, _ := partition.IsServiceAvailableIn(serviceID, id); id != regionID && available {
+			regions = append(regions, r)
+		}
+	}
+
+	sort.Slice(regions, func(i, j int) bool {
+		di, dj := regionDistance(region, regions[i]), regionDistance(region, regions[j])
+		if di != dj {
+			return di < dj
+		}
+		return regions[i].ID() < regions[j].ID()
+	})
+
+	if len(regions) > maxSuggestedRegions {
+		regions = regions[:maxSuggestedRegions]
+	}
+
+	ids := make([]string, len(regions))
+	for i, r := range regions {
+		ids[i] = r.ID()
+	}
+
+	return ids
+}
+
+// regionDistance estimates the distance between two Regions.
+// Regions in different geographies are furthest apart. Otherwise the distance is estimated from the Region IDs,
+// which have the form "{area}-{direction}-{number}", e.g. "us-gov-west-1".
+func regionDistance(a, b endpoints.Region) int {
+	const (
+		geographyWeight = 100
+		directionWeight = 10
+	)
+
+	da, na := splitRegionID(a.ID())
+	db, nb := splitRegionID(b.ID())
+
+	distance := 0
+	if a.Geography() == endpoints.GeographyUnknown || a.Geography() != b.Geography() {
+		distance += geographyWeight
+	}
+	if da != db {
+		distance += directionWeight
+	}
+	if na > nb {
+		distance += na - nb
+	} else {
+		distance += nb - na
+	}
+
+	return distance
+}
+
+// splitRegionID returns the direction and number parts of a Region ID.
+func splitRegionID(id string) (string, int) {
+	parts := strings.Split(id, "-")
+	if len(parts) < 3 { //nolint:mnd
+		return "", 0
+	}
+
+	n, _ := strconv.Atoi(parts[len(parts)-1])
+
+	return parts[len(parts)-2], n
+}
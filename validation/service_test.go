@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/aws-sdk-go-base/v2/diag"
+)
+
+func TestSupportedServices(t *testing.T) {
+	testcases := map[string]struct {
+		Region        string
+		ServiceIDs    []string
+		ExpectedDiags diag.Diagnostics
+	}{
+		"all available": {
+			Region:     "eu-south-2",
+			ServiceIDs: []string{"dynamodb", "iam", "s3"},
+		},
+		"not available": {
+			Region:     "eu-south-2",
+			ServiceIDs: []string{"dynamodb", "appflow"},
+			ExpectedDiags: diag.Diagnostics{
+				ServiceNotAvailableError{
+					serviceID:        "appflow",
+					region:           "eu-south-2",
+					partition:        "aws",
+					suggestedRegions: []string{"eu-west-2", "eu-central-1", "eu-west-1"},
+				},
+			},
+		},
+		"not available in geography": {
+			Region:     "ca-west-1",
+			ServiceIDs: []string{"appflow"},
+			ExpectedDiags: diag.Diagnostics{
+				ServiceNotAvailableError{
+					serviceID:        "appflow",
+					region:           "ca-west-1",
+					partition:        "aws",
+					suggestedRegions: []string{"us-west-1", "us-west-2", "ca-central-1"},
+				},
+			},
+		},
+		"recently launched region": {
+			Region:     "mx-central-1",
+			ServiceIDs: []string{"ec2", "s3", "sts"},
+		},
+		"unknown service": {
+			Region:     "us-east-1",
+			ServiceIDs: []string{"not-a-service"},
+			ExpectedDiags: diag.Diagnostics{
+				ServiceNotAvailableError{
+					serviceID: "not-a-service",
+					region:    "us-east-1",
+					partition: "aws",
+				},
+			},
+		},
+		"invalid region": {
+			Region:     "not-a-region",
+			ServiceIDs: []string{"dynamodb"},
+			ExpectedDiags: diag.Diagnostics{
+				diag.NewNativeErrorDiagnostic(&InvalidRegionError{
+					region: "not-a-region",
+				}),
+			},
+		},
+	}
+
+	for name, testcase := range testcases {
+		testcase := testcase
+
+		t.Run(name, func(t *testing.T) {
+			diags := SupportedServices(testcase.Region, testcase.ServiceIDs...)
+
+			if diff := cmp.Diff(diags, testcase.ExpectedDiags, cmp.AllowUnexported(ServiceNotAvailableError{}, InvalidRegionError{}), cmp.Comparer(func(a, b diag.NativeErrorDiagnostic) bool {
+				return a.Equal(b)
+			})); diff != "" {
+				t.Errorf("unexpected diagnostics difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestServiceNotAvailableErrorDetail(t *testing.T) {
+	e := ServiceNotAvailableError{
+		serviceID:        "appflow",
+		region:           "eu-south-2",
+		partition:        "aws",
+		suggestedRegions: []string{"eu-west-2", "eu-central-1"},
+	}
+
+	expected := `The service "appflow" is not available in the AWS Region "eu-south-2" in partition "aws".
+
+The service is available in these nearby Regions: eu-west-2, eu-central-1`
+	if a := e.Detail(); a != expected {
+		t.Errorf("expected detail %q, got %q", expected, a)
+	}
+}